@@ -0,0 +1,9 @@
+data "typesense_document" "feature_flags" {
+  id              = "checkout"
+  collection_name = "feature-flags"
+  exclude_fields  = ["updated_by"]
+}
+
+output "new_checkout_enabled" {
+  value = jsondecode(data.typesense_document.feature_flags.document).enabled
+}
@@ -0,0 +1,18 @@
+data "typesense_search" "iphone" {
+  collection_name = typesense_collection.products.name
+  q               = "iphone"
+  query_by        = "title"
+  filter_by       = "in_stock:true"
+  per_page        = 5
+}
+
+check "products_seeded" {
+  assert {
+    condition     = data.typesense_search.iphone.found > 0
+    error_message = "Searching for iphone in products returned no hits."
+  }
+}
+
+output "top_iphone_title" {
+  value = jsondecode(data.typesense_search.iphone.hits[0]).title
+}
@@ -0,0 +1,25 @@
+locals {
+  # Same body as sent to POST /collections by the application
+  products_schema = file("${path.module}/schemas/products.json")
+}
+
+resource "typesense_collection" "products" {
+  name = jsondecode(local.products_schema).name
+
+  dynamic "fields" {
+    for_each = provider::typesense::collection_fields_from_json(local.products_schema)
+
+    content {
+      name     = fields.value.name
+      type     = fields.value.type
+      facet    = fields.value.facet
+      index    = fields.value.index
+      optional = fields.value.optional
+      sort     = fields.value.sort
+      infix    = fields.value.infix
+      locale   = fields.value.locale
+      store    = fields.value.store
+      num_dim  = fields.value.num_dim
+    }
+  }
+}
@@ -0,0 +1,16 @@
+resource "typesense_api_key" "search" {
+  description = "Search-only key"
+  actions     = ["documents:search"]
+  collections = ["products"]
+}
+
+output "tenant_search_key" {
+  sensitive = true
+  value = provider::typesense::scoped_search_key(
+    typesense_api_key.search.value,
+    jsonencode({
+      filter_by  = "tenant_id:42"
+      expires_at = 1906054106
+    })
+  )
+}
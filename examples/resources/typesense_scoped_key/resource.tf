@@ -0,0 +1,13 @@
+resource "typesense_api_key" "search" {
+  description = "Search-only key"
+  actions     = ["documents:search"]
+  collections = ["products"]
+}
+
+resource "typesense_scoped_key" "tenant_42" {
+  parent_key           = typesense_api_key.search.value
+  filter_by            = "tenant_id:42"
+  validity             = "720h"
+  limit_multi_searches = 5
+  rotate_before        = "2030-05-01T00:00:00Z"
+}
@@ -0,0 +1,158 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/typesense/typesense-go/v3/typesense"
+	"github.com/typesense/typesense-go/v3/typesense/api"
+	"github.com/typesense/typesense-go/v3/typesense/api/circuit"
+)
+
+// typesenseClient is the typesense-go client along with the low-level API
+// client it is built on. The low-level client is only used for the collection
+// endpoints whose attributes typesense-go does not model yet, and shares the
+// circuit breaker of the typesense-go client.
+type typesenseClient struct {
+	*typesense.Client
+
+	api *api.ClientWithResponses
+}
+
+// defaultRequestTimeout bounds the requests whose context has no deadline.
+// Collection and document requests are bounded by the deadline of their
+// timeouts block instead, as schema changes on large collections block for
+// much longer than a regular request.
+const defaultRequestTimeout = 30 * time.Second
+
+// newTypesenseClient returns a client that applies defaultRequestTimeout to
+// the requests whose context has no deadline. It fails if apiAddress is not
+// an absolute URL, which the API client would only report on the first
+// request.
+func newTypesenseClient(apiAddress string, apiKey string) (*typesenseClient, error) {
+	serverURL, err := url.Parse(apiAddress)
+	if err != nil {
+		return nil, err
+	}
+	if serverURL.Scheme == "" || serverURL.Host == "" {
+		return nil, fmt.Errorf("%q is not an absolute URL, e.g. http://localhost:8108", apiAddress)
+	}
+
+	config := &typesense.ClientConfig{
+		ServerURL: apiAddress,
+		APIKey:    apiKey,
+	}
+
+	breaker := circuit.NewGoBreaker(
+		circuit.WithGoBreakerName("typesenseClient"),
+		circuit.WithGoBreakerMaxRequests(50),
+		circuit.WithGoBreakerInterval(2*time.Minute),
+		circuit.WithGoBreakerTimeout(1*time.Minute),
+		circuit.WithGoBreakerReadyToTrip(circuit.DefaultReadyToTrip))
+
+	httpClient := circuit.NewHTTPClient(
+		circuit.WithHTTPRequestDoer(typesense.NewAPICall(&http.Client{
+			Transport: &defaultTimeoutTransport{base: http.DefaultTransport, timeout: defaultRequestTimeout},
+		}, config)),
+		circuit.WithCircuitBreaker(breaker))
+
+	apiClient, err := api.NewClientWithResponses(apiAddress,
+		api.WithAPIKey(apiKey),
+		api.WithHTTPClient(httpClient))
+	if err != nil {
+		return nil, err
+	}
+
+	return &typesenseClient{
+		Client: typesense.NewClient(typesense.WithAPIClient(apiClient)),
+		api:    apiClient,
+	}, nil
+}
+
+// jsonBody encodes body as the JSON request body of a low-level API call.
+func jsonBody(body interface{}) (io.Reader, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(data), nil
+}
+
+// decodeResponse decodes the JSON response of a low-level API call into out.
+// Non 2xx responses are returned as *typesense.HTTPError, same as the
+// typesense-go client does.
+func decodeResponse(resp *http.Response, err error, out interface{}) error {
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &typesense.HTTPError{Status: resp.StatusCode, Body: respBody}
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	return json.Unmarshal(respBody, out)
+}
+
+// withQuery adds query parameters the low-level API client does not model
+// to a request.
+func withQuery(key string, value string) api.RequestEditorFn {
+	return func(_ context.Context, req *http.Request) error {
+		query := req.URL.Query()
+		query.Set(key, value)
+		req.URL.RawQuery = query.Encode()
+		return nil
+	}
+}
+
+// defaultTimeoutTransport applies a timeout to the requests whose context has
+// no deadline, the requests with a deadline are sent as is.
+type defaultTimeoutTransport struct {
+	base    http.RoundTripper
+	timeout time.Duration
+}
+
+func (t *defaultTimeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if _, ok := req.Context().Deadline(); ok {
+		return t.base.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	// The timeout also covers reading the body, same as http.Client.Timeout.
+	resp.Body = &cancelOnCloseBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelOnCloseBody releases the timeout of a request once its response body
+// is closed.
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
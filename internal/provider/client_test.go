@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDefaultTimeoutTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		_, _ = io.WriteString(w, "{}")
+	}))
+	t.Cleanup(server.Close)
+
+	client := &http.Client{
+		Transport: &defaultTimeoutTransport{base: http.DefaultTransport, timeout: 50 * time.Millisecond},
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	if _, err := client.Do(req); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the default timeout without a context deadline, got %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		t.Fatalf("expected the context deadline to replace the default timeout, got %v", err)
+	}
+	defer resp.Body.Close()
+
+	if body, _ := io.ReadAll(resp.Body); string(body) != "{}" {
+		t.Errorf("unexpected body %q", body)
+	}
+}
+
+func TestNewTypesenseClient_InvalidAddress(t *testing.T) {
+	for _, address := range []string{"localhost:8108", "http://", "http://local host:8108"} {
+		if _, err := newTypesenseClient(address, "test-api-key"); err == nil {
+			t.Errorf("expected an error for address %q", address)
+		}
+	}
+}
+
+// testTypesenseClient returns a client for the test server at serverURL.
+func testTypesenseClient(t *testing.T, serverURL string) *typesenseClient {
+	t.Helper()
+
+	client, err := newTypesenseClient(serverURL, "test-api-key")
+	if err != nil {
+		t.Fatalf("unable to create client: %s", err)
+	}
+
+	return client
+}
@@ -0,0 +1,356 @@
+package provider
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/typesense/typesense-go/v3/typesense"
+	"github.com/typesense/typesense-go/v3/typesense/api"
+)
+
+// collectionFieldAPI extends api.Field with the field attributes typesense-go
+// does not model yet.
+type collectionFieldAPI struct {
+	api.Field
+	AsyncReference *bool               `json:"async_reference,omitempty"`
+	HnswParams     *fieldHnswParamsAPI `json:"hnsw_params,omitempty"`
+}
+
+// fieldHnswParamsAPI holds the HNSW index parameters of a vector field.
+type fieldHnswParamsAPI struct {
+	M              *int `json:"M,omitempty"`
+	EfConstruction *int `json:"ef_construction,omitempty"`
+}
+
+// collectionSchemaAPI is api.CollectionSchema with extended fields.
+type collectionSchemaAPI struct {
+	api.CollectionSchema
+	Fields   []collectionFieldAPI    `json:"fields"`
+	Metadata *map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// collectionResponseAPI is api.CollectionResponse with extended fields.
+type collectionResponseAPI struct {
+	api.CollectionResponse
+	Fields   []collectionFieldAPI   `json:"fields"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// collectionUpdateSchemaAPI is api.CollectionUpdateSchema with extended fields.
+type collectionUpdateSchemaAPI struct {
+	Fields   []collectionFieldAPI    `json:"fields,omitempty"`
+	Metadata *map[string]interface{} `json:"metadata,omitempty"`
+}
+
+func (c *typesenseClient) createCollection(ctx context.Context, schema *collectionSchemaAPI) (*collectionResponseAPI, error) {
+	body, err := jsonBody(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	collection := &collectionResponseAPI{}
+	resp, err := c.api.CreateCollectionWithBody(ctx, "application/json", body)
+	if err := decodeResponse(resp, err, collection); err != nil {
+		return nil, err
+	}
+	return collection, nil
+}
+
+// cloneCollection creates a collection with the schema, synonyms and
+// overrides of an existing one, without its documents.
+func (c *typesenseClient) cloneCollection(ctx context.Context, source string, name string) (*collectionResponseAPI, error) {
+	body, err := jsonBody(map[string]string{"name": name})
+	if err != nil {
+		return nil, err
+	}
+
+	collection := &collectionResponseAPI{}
+	resp, err := c.api.CreateCollectionWithBody(ctx, "application/json", body, withQuery("src_name", source))
+	if err := decodeResponse(resp, err, collection); err != nil {
+		return nil, err
+	}
+	return collection, nil
+}
+
+func (c *typesenseClient) retrieveCollection(ctx context.Context, name string) (*collectionResponseAPI, error) {
+	collection := &collectionResponseAPI{}
+	resp, err := c.api.GetCollection(ctx, name)
+	if err := decodeResponse(resp, err, collection); err != nil {
+		return nil, err
+	}
+	return collection, nil
+}
+
+func (c *typesenseClient) updateCollection(ctx context.Context, name string, schema *collectionUpdateSchemaAPI) error {
+	body, err := jsonBody(schema)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.api.UpdateCollectionWithBody(ctx, name, "application/json", body)
+	return decodeResponse(resp, err, nil)
+}
+
+// truncateCollectionDocuments deletes all documents of a collection, keeping
+// its schema.
+func (c *typesenseClient) truncateCollectionDocuments(ctx context.Context, name string) error {
+	truncate := true
+	_, err := c.Collection(name).Documents().Delete(ctx, &api.DeleteDocumentsParams{Truncate: &truncate})
+	return err
+}
+
+// schemaChangeAPI is the progress of a schema alteration running in the
+// background.
+type schemaChangeAPI struct {
+	Collection    string `json:"collection"`
+	ValidatedDocs int64  `json:"validated_docs"`
+	AlteredDocs   int64  `json:"altered_docs"`
+}
+
+// schemaChangePollInterval is the delay between two checks of a schema
+// alteration in progress.
+var schemaChangePollInterval = 2 * time.Second
+
+// collectionSchemaChange returns the schema alteration in progress on a
+// collection, or nil if there is none. Typesense versions without the
+// schema changes endpoint are treated as having no alteration in progress.
+func (c *typesenseClient) collectionSchemaChange(ctx context.Context, name string) (*schemaChangeAPI, error) {
+	var changes []schemaChangeAPI
+
+	resp, err := c.api.GetSchemaChanges(ctx)
+	if err := decodeResponse(resp, err, &changes); err != nil {
+		var httpErr *typesense.HTTPError
+		if errors.As(err, &httpErr) && httpErr.Status == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	for _, change := range changes {
+		if change.Collection == name {
+			return &change, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// waitForSchemaChange polls the schema alteration in progress on a collection
+// until it completes or the context is done.
+func (c *typesenseClient) waitForSchemaChange(ctx context.Context, name string) error {
+	for {
+		change, err := c.collectionSchemaChange(ctx, name)
+		if err != nil {
+			return err
+		}
+
+		if change == nil {
+			return nil
+		}
+
+		tflog.Info(ctx, fmt.Sprintf("###Altering collection %s: %d documents validated, %d altered", name, change.ValidatedDocs, change.AlteredDocs))
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("collection %s is still being altered: %w", name, ctx.Err())
+		case <-time.After(schemaChangePollInterval):
+		}
+	}
+}
+
+// reportSchemaChangeProgress logs the progress of the schema alteration on a
+// collection every schemaChangePollInterval until the context is done. Failed
+// checks are only logged, they do not affect the alteration.
+func (c *typesenseClient) reportSchemaChangeProgress(ctx context.Context, name string) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(schemaChangePollInterval):
+		}
+
+		change, err := c.collectionSchemaChange(ctx, name)
+
+		switch {
+		case ctx.Err() != nil:
+			return
+		case err != nil:
+			tflog.Warn(ctx, "###Unable to check the alteration of collection "+name+": "+err.Error())
+		case change != nil:
+			tflog.Info(ctx, fmt.Sprintf("###Altering collection %s: %d documents validated, %d altered", name, change.ValidatedDocs, change.AlteredDocs))
+		}
+	}
+}
+
+// copyCollectionDocuments exports all documents of a collection and imports
+// them into another one, failing if any document is rejected.
+func (c *typesenseClient) copyCollectionDocuments(ctx context.Context, from string, to string) error {
+	export, err := c.Collection(from).Documents().Export(ctx, &api.ExportDocumentsParams{})
+	if err != nil {
+		return err
+	}
+	defer export.Close()
+
+	documents := bufio.NewReader(export)
+
+	// Importing an empty body is rejected, there is nothing to copy anyway
+	if _, err := documents.Peek(1); err == io.EOF {
+		return nil
+	}
+
+	action := api.Create
+	result, err := c.Collection(to).Documents().ImportJsonl(ctx, documents, &api.ImportDocumentsParams{Action: &action})
+	if err != nil {
+		return err
+	}
+	defer result.Close()
+
+	return checkImportResult(result)
+}
+
+// copyCollectionSynonyms upserts all synonyms of a collection into another
+// one, keeping their ids.
+func (c *typesenseClient) copyCollectionSynonyms(ctx context.Context, from string, to string) error {
+	synonyms, err := c.Collection(from).Synonyms().Retrieve(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, synonym := range synonyms {
+		_, err := c.Collection(to).Synonyms().Upsert(ctx, *synonym.Id, &api.SearchSynonymSchema{
+			Locale:         synonym.Locale,
+			Root:           synonym.Root,
+			SymbolsToIndex: synonym.SymbolsToIndex,
+			Synonyms:       synonym.Synonyms,
+		})
+		if err != nil {
+			return fmt.Errorf("synonym %s: %w", *synonym.Id, err)
+		}
+	}
+
+	return nil
+}
+
+// copyCollectionOverrides upserts all overrides of a collection into another
+// one, keeping their ids.
+func (c *typesenseClient) copyCollectionOverrides(ctx context.Context, from string, to string) error {
+	overrides, err := c.Collection(from).Overrides().Retrieve(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, override := range overrides {
+		_, err := c.Collection(to).Overrides().Upsert(ctx, *override.Id, &api.SearchOverrideSchema{
+			EffectiveFromTs:     override.EffectiveFromTs,
+			EffectiveToTs:       override.EffectiveToTs,
+			Excludes:            override.Excludes,
+			FilterBy:            override.FilterBy,
+			FilterCuratedHits:   override.FilterCuratedHits,
+			Includes:            override.Includes,
+			Metadata:            override.Metadata,
+			RemoveMatchedTokens: override.RemoveMatchedTokens,
+			ReplaceQuery:        override.ReplaceQuery,
+			Rule:                override.Rule,
+			SortBy:              override.SortBy,
+			StopProcessing:      override.StopProcessing,
+		})
+		if err != nil {
+			return fmt.Errorf("override %s: %w", *override.Id, err)
+		}
+	}
+
+	return nil
+}
+
+// checkImportResult reads the JSONL response of a documents import and
+// returns the first error reported for a document.
+func checkImportResult(result io.Reader) error {
+	scanner := bufio.NewScanner(result)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	failed := 0
+	var firstError string
+
+	for scanner.Scan() {
+		var response api.ImportDocumentResponse
+		if err := json.Unmarshal(scanner.Bytes(), &response); err != nil {
+			return err
+		}
+
+		if !response.Success {
+			if failed == 0 {
+				firstError = response.Error
+			}
+			failed++
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d documents were rejected, first error: %s", failed, firstError)
+	}
+
+	return nil
+}
+
+// backupCollectionDocuments exports all documents of a collection to a JSONL
+// file, optionally gzip compressed. The file is written next to its final
+// path first so that a failed export never leaves a truncated backup behind.
+func (c *typesenseClient) backupCollectionDocuments(ctx context.Context, name string, path string, compress bool) error {
+	export, err := c.Collection(name).Documents().Export(ctx, &api.ExportDocumentsParams{})
+	if err != nil {
+		return err
+	}
+	defer export.Close()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if err := writeBackup(file, export, compress); err != nil {
+		file.Close()
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), path)
+}
+
+func writeBackup(file io.Writer, documents io.Reader, compress bool) error {
+	if !compress {
+		_, err := io.Copy(file, documents)
+		return err
+	}
+
+	writer := gzip.NewWriter(file)
+
+	if _, err := io.Copy(writer, documents); err != nil {
+		writer.Close()
+		return err
+	}
+
+	return writer.Close()
+}
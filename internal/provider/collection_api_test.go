@@ -0,0 +1,232 @@
+package provider
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const testBackupDocuments = "{\"id\":\"1\",\"title\":\"Shoe\"}\n{\"id\":\"2\",\"title\":\"Sneaker\"}\n"
+
+func newTestExportServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-TYPESENSE-API-KEY") != "test-api-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/collections/products/documents/export":
+			_, _ = io.WriteString(w, testBackupDocuments)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `{"message": "Not Found"}`)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestBackupCollectionDocuments(t *testing.T) {
+	server := newTestExportServer(t)
+	client := testTypesenseClient(t, server.URL)
+
+	backupPath := filepath.Join(t.TempDir(), "backups", "products.jsonl")
+
+	if err := client.backupCollectionDocuments(context.Background(), "products", backupPath, false); err != nil {
+		t.Fatalf("unable to backup collection: %s", err)
+	}
+
+	content, err := os.ReadFile(backupPath)
+	if err != nil {
+		t.Fatalf("unable to read backup: %s", err)
+	}
+
+	if string(content) != testBackupDocuments {
+		t.Errorf("expected backup %q, got %q", testBackupDocuments, string(content))
+	}
+}
+
+func TestBackupCollectionDocuments_Gzip(t *testing.T) {
+	server := newTestExportServer(t)
+	client := testTypesenseClient(t, server.URL)
+
+	backupPath := filepath.Join(t.TempDir(), "products.jsonl.gz")
+
+	if err := client.backupCollectionDocuments(context.Background(), "products", backupPath, true); err != nil {
+		t.Fatalf("unable to backup collection: %s", err)
+	}
+
+	file, err := os.Open(backupPath)
+	if err != nil {
+		t.Fatalf("unable to open backup: %s", err)
+	}
+	defer file.Close()
+
+	reader, err := gzip.NewReader(file)
+	if err != nil {
+		t.Fatalf("backup is not gzip compressed: %s", err)
+	}
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("unable to read backup: %s", err)
+	}
+
+	if string(content) != testBackupDocuments {
+		t.Errorf("expected backup %q, got %q", testBackupDocuments, string(content))
+	}
+}
+
+func TestBackupCollectionDocuments_ExportError(t *testing.T) {
+	server := newTestExportServer(t)
+	client := testTypesenseClient(t, server.URL)
+
+	dir := t.TempDir()
+	backupPath := filepath.Join(dir, "missing.jsonl")
+
+	if err := client.backupCollectionDocuments(context.Background(), "missing", backupPath, false); err == nil {
+		t.Fatal("expected an error for a missing collection")
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("unable to list backup directory: %s", err)
+	}
+
+	if len(entries) != 0 {
+		t.Errorf("expected no backup file to be written, got %d entries", len(entries))
+	}
+}
+
+func TestTruncateCollectionDocuments(t *testing.T) {
+	var query string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/collections/products/documents" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `{"message": "Not Found"}`)
+			return
+		}
+
+		query = r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"num_deleted": 2}`)
+	}))
+	t.Cleanup(server.Close)
+
+	client := testTypesenseClient(t, server.URL)
+
+	if err := client.truncateCollectionDocuments(context.Background(), "products"); err != nil {
+		t.Fatalf("unable to truncate collection: %s", err)
+	}
+
+	if query != "truncate=true" {
+		t.Errorf("expected truncate=true query, got %q", query)
+	}
+}
+
+func TestCloneCollection(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		if r.Method != http.MethodPost || r.URL.Path != "/collections" || r.URL.Query().Get("src_name") != "products" || string(body) != `{"name":"products_preview"}` {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = io.WriteString(w, `{"message": "Bad Request"}`)
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = io.WriteString(w, `{"name": "products_preview", "fields": [{"name": "title", "type": "string"}]}`)
+	}))
+	t.Cleanup(server.Close)
+
+	client := testTypesenseClient(t, server.URL)
+
+	collection, err := client.cloneCollection(context.Background(), "products", "products_preview")
+	if err != nil {
+		t.Fatalf("unable to clone collection: %s", err)
+	}
+
+	if collection.Name != "products_preview" || len(collection.Fields) != 1 || collection.Fields[0].Name != "title" {
+		t.Errorf("expected cloned collection with title field, got %+v", collection)
+	}
+}
+
+func TestWaitForSchemaChange(t *testing.T) {
+	pollInterval := schemaChangePollInterval
+	schemaChangePollInterval = time.Millisecond
+	t.Cleanup(func() { schemaChangePollInterval = pollInterval })
+
+	polls := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/operations/schema_changes" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		polls++
+		w.Header().Set("Content-Type", "application/json")
+
+		if polls < 3 {
+			_, _ = io.WriteString(w, `[{"collection": "orders", "validated_docs": 10, "altered_docs": 0}, {"collection": "products", "validated_docs": 100, "altered_docs": 50}]`)
+			return
+		}
+
+		_, _ = io.WriteString(w, `[{"collection": "orders", "validated_docs": 10, "altered_docs": 0}]`)
+	}))
+	t.Cleanup(server.Close)
+
+	client := testTypesenseClient(t, server.URL)
+
+	if err := client.waitForSchemaChange(context.Background(), "products"); err != nil {
+		t.Fatalf("unable to wait for schema change: %s", err)
+	}
+
+	if polls != 3 {
+		t.Errorf("expected 3 polls, got %d", polls)
+	}
+}
+
+func TestWaitForSchemaChange_Timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `[{"collection": "products", "validated_docs": 100, "altered_docs": 50}]`)
+	}))
+	t.Cleanup(server.Close)
+
+	client := testTypesenseClient(t, server.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := client.waitForSchemaChange(ctx, "products")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded error, got %v", err)
+	}
+}
+
+func TestCollectionSchemaChange_NotSupported(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = io.WriteString(w, `{"message": "Not Found"}`)
+	}))
+	t.Cleanup(server.Close)
+
+	client := testTypesenseClient(t, server.URL)
+
+	change, err := client.collectionSchemaChange(context.Background(), "products")
+	if err != nil || change != nil {
+		t.Errorf("expected no schema change, got %v, %v", change, err)
+	}
+}
@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/typesense/typesense-go/v3/typesense"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &DocumentDataSource{}
+
+func NewDocumentDataSource() datasource.DataSource {
+	return &DocumentDataSource{}
+}
+
+type DocumentDataSource struct {
+	client *typesense.Client
+}
+
+type DocumentDataSourceModel struct {
+	Id             types.String         `tfsdk:"id"`
+	CollectionName types.String         `tfsdk:"collection_name"`
+	IncludeFields  []types.String       `tfsdk:"include_fields"`
+	ExcludeFields  []types.String       `tfsdk:"exclude_fields"`
+	Document       jsontypes.Normalized `tfsdk:"document"`
+}
+
+func (d *DocumentDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_document"
+}
+
+func (d *DocumentDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads a single document from a collection, e.g. to consume configuration stored in Typesense from other modules",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Document id",
+				Required:            true,
+			},
+			"collection_name": schema.StringAttribute{
+				MarkdownDescription: "Collection name",
+				Required:            true,
+			},
+			"include_fields": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Top-level document fields to keep in `document`. All fields are kept when omitted.",
+				Validators: []validator.List{
+					listvalidator.ConflictsWith(path.MatchRoot("exclude_fields")),
+				},
+			},
+			"exclude_fields": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Top-level document fields to remove from `document`",
+			},
+			"document": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Document object in JSON format, without the `id` field",
+				CustomType:          jsontypes.NormalizedType{},
+			},
+		},
+	}
+}
+
+func (d *DocumentDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*typesense.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *typesense.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *DocumentDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DocumentDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := d.client.Collection(data.CollectionName.ValueString()).Document(data.Id.ValueString()).Retrieve(ctx)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to retrieve document, got error: %s", err))
+		return
+	}
+
+	delete(result, "id")
+
+	result = filterDocumentFields(result,
+		convertTerraformArrayToStringArray(data.IncludeFields),
+		convertTerraformArrayToStringArray(data.ExcludeFields))
+
+	data.Document, err = parseMapToJsonString(result)
+
+	if err != nil {
+		resp.Diagnostics.AddError("JSON format error", fmt.Sprintf("Unable to parse json response, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
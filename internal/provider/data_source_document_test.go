@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDocumentDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDocumentDataSourceConfig("test_collection_doc_data"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.typesense_document.test", "id", "settings"),
+					resource.TestCheckResourceAttr("data.typesense_document.test", "collection_name", "test_collection_doc_data"),
+					resource.TestCheckResourceAttr("data.typesense_document.test", "document", `{"enabled":true,"flag":"new_checkout","rollout":50}`),
+					resource.TestCheckResourceAttr("data.typesense_document.include", "document", `{"flag":"new_checkout"}`),
+					resource.TestCheckResourceAttr("data.typesense_document.exclude", "document", `{"enabled":true,"flag":"new_checkout"}`),
+				),
+			},
+		},
+	})
+}
+
+func testAccDocumentDataSourceConfig(collectionName string) string {
+	return fmt.Sprintf(`
+resource "typesense_collection" "test" {
+  name = %[1]q
+
+  fields {
+    name = "flag"
+    type = "string"
+  }
+
+  fields {
+    name = "enabled"
+    type = "bool"
+  }
+
+  fields {
+    name = "rollout"
+    type = "int32"
+  }
+}
+
+resource "typesense_document" "test" {
+  name            = "settings"
+  collection_name = typesense_collection.test.name
+  document = jsonencode({
+    flag    = "new_checkout"
+    enabled = true
+    rollout = 50
+  })
+}
+
+data "typesense_document" "test" {
+  id              = typesense_document.test.name
+  collection_name = typesense_document.test.collection_name
+}
+
+data "typesense_document" "include" {
+  id              = typesense_document.test.name
+  collection_name = typesense_document.test.collection_name
+  include_fields  = ["flag"]
+}
+
+data "typesense_document" "exclude" {
+  id              = typesense_document.test.name
+  collection_name = typesense_document.test.collection_name
+  exclude_fields  = ["rollout"]
+}
+`, collectionName)
+}
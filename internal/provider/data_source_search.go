@@ -0,0 +1,177 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/typesense/typesense-go/v3/typesense"
+	"github.com/typesense/typesense-go/v3/typesense/api"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &SearchDataSource{}
+
+func NewSearchDataSource() datasource.DataSource {
+	return &SearchDataSource{}
+}
+
+type SearchDataSource struct {
+	client *typesense.Client
+}
+
+type SearchDataSourceModel struct {
+	Id             types.String           `tfsdk:"id"`
+	CollectionName types.String           `tfsdk:"collection_name"`
+	Q              types.String           `tfsdk:"q"`
+	QueryBy        types.String           `tfsdk:"query_by"`
+	FilterBy       types.String           `tfsdk:"filter_by"`
+	SortBy         types.String           `tfsdk:"sort_by"`
+	PerPage        types.Int64            `tfsdk:"per_page"`
+	Preset         types.String           `tfsdk:"preset"`
+	Found          types.Int64            `tfsdk:"found"`
+	OutOf          types.Int64            `tfsdk:"out_of"`
+	Hits           []jsontypes.Normalized `tfsdk:"hits"`
+}
+
+func (d *SearchDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_search"
+}
+
+func (d *SearchDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Runs a search against a collection, e.g. to derive values from indexed documents or to assert on search results in `check` blocks",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Id identifier",
+			},
+			"collection_name": schema.StringAttribute{
+				MarkdownDescription: "Collection name",
+				Required:            true,
+			},
+			"q": schema.StringAttribute{
+				MarkdownDescription: "Query text, use `*` to match all documents",
+				Required:            true,
+			},
+			"query_by": schema.StringAttribute{
+				MarkdownDescription: "Comma separated list of fields to query against. Required unless `q` is `*` or it is provided by `preset`.",
+				Optional:            true,
+			},
+			"filter_by": schema.StringAttribute{
+				MarkdownDescription: "Filter conditions for refining the search results",
+				Optional:            true,
+			},
+			"sort_by": schema.StringAttribute{
+				MarkdownDescription: "Comma separated list of fields and their sort orders",
+				Optional:            true,
+			},
+			"per_page": schema.Int64Attribute{
+				MarkdownDescription: "Number of hits to return. Defaults to 10 on the server side.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(0, 250),
+				},
+			},
+			"preset": schema.StringAttribute{
+				MarkdownDescription: "Name of a preset holding search parameters",
+				Optional:            true,
+			},
+			"found": schema.Int64Attribute{
+				MarkdownDescription: "Number of documents matching the search",
+				Computed:            true,
+			},
+			"out_of": schema.Int64Attribute{
+				MarkdownDescription: "Total number of documents in the collection",
+				Computed:            true,
+			},
+			"hits": schema.ListAttribute{
+				ElementType:         jsontypes.NormalizedType{},
+				MarkdownDescription: "Documents of the returned hits in JSON format",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *SearchDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*typesense.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *typesense.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *SearchDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SearchDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := &api.SearchCollectionParams{
+		Q:        data.Q.ValueStringPointer(),
+		QueryBy:  data.QueryBy.ValueStringPointer(),
+		FilterBy: data.FilterBy.ValueStringPointer(),
+		SortBy:   data.SortBy.ValueStringPointer(),
+		Preset:   data.Preset.ValueStringPointer(),
+	}
+
+	if !data.PerPage.IsNull() {
+		perPage := int(data.PerPage.ValueInt64())
+		params.PerPage = &perPage
+	}
+
+	result, err := d.client.Collection(data.CollectionName.ValueString()).Documents().Search(ctx, params)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to search collection, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(data.CollectionName.ValueString())
+	data.Found = intPointerValue(result.Found)
+	data.OutOf = intPointerValue(result.OutOf)
+
+	data.Hits = []jsontypes.Normalized{}
+	if result.Hits != nil {
+		for _, hit := range *result.Hits {
+			document := map[string]interface{}{}
+			if hit.Document != nil {
+				document = *hit.Document
+			}
+
+			value, err := parseMapToJsonString(document)
+			if err != nil {
+				resp.Diagnostics.AddError("JSON format error", fmt.Sprintf("Unable to parse json response, got error: %s", err))
+				return
+			}
+
+			data.Hits = append(data.Hits, value)
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSearchDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSearchDataSourceConfig("test_collection_search"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.typesense_search.test", "found", "1"),
+					resource.TestCheckResourceAttr("data.typesense_search.test", "out_of", "2"),
+					resource.TestCheckResourceAttr("data.typesense_search.test", "hits.#", "1"),
+					resource.TestCheckResourceAttr("data.typesense_search.test", "hits.0", `{"id":"iphone","price":999,"title":"iPhone 15"}`),
+					resource.TestCheckResourceAttr("data.typesense_search.all", "found", "2"),
+					resource.TestCheckResourceAttr("data.typesense_search.all", "hits.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccSearchDataSourceConfig(collectionName string) string {
+	return fmt.Sprintf(`
+resource "typesense_collection" "test" {
+  name = %[1]q
+
+  fields {
+    name = "title"
+    type = "string"
+  }
+
+  fields {
+    name = "price"
+    type = "int32"
+    sort = true
+  }
+}
+
+resource "typesense_document" "iphone" {
+  name            = "iphone"
+  collection_name = typesense_collection.test.name
+  document = jsonencode({
+    title = "iPhone 15"
+    price = 999
+  })
+}
+
+resource "typesense_document" "pixel" {
+  name            = "pixel"
+  collection_name = typesense_collection.test.name
+  document = jsonencode({
+    title = "Pixel 8"
+    price = 699
+  })
+}
+
+data "typesense_search" "test" {
+  collection_name = typesense_collection.test.name
+  q               = "iphone"
+  query_by        = "title"
+
+  depends_on = [typesense_document.iphone, typesense_document.pixel]
+}
+
+data "typesense_search" "all" {
+  collection_name = typesense_collection.test.name
+  q               = "*"
+  filter_by       = "price:>500"
+  sort_by         = "price:desc"
+  per_page        = 1
+
+  depends_on = [typesense_document.iphone, typesense_document.pixel]
+}
+`, collectionName)
+}
@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &CollectionFieldsFromJsonFunction{}
+
+func NewCollectionFieldsFromJsonFunction() function.Function {
+	return &CollectionFieldsFromJsonFunction{}
+}
+
+type CollectionFieldsFromJsonFunction struct{}
+
+func (f *CollectionFieldsFromJsonFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "collection_fields_from_json"
+}
+
+func (f *CollectionFieldsFromJsonFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Parse collection fields from a Typesense schema",
+		MarkdownDescription: "Parses a collection schema in JSON format, i.e. the body sent to `POST /collections`, and returns its fields as a list of objects with the same attributes as the `fields` block of `typesense_collection`, with defaults applied. Use it in a `dynamic \"fields\"` block to keep the collection in sync with a canonical schema file.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "schema_json",
+				MarkdownDescription: "Collection schema in JSON format, e.g. `file(\"schemas/products.json\")`",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: collectionFieldObjectType(ctx),
+		},
+	}
+}
+
+func (f *CollectionFieldsFromJsonFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var schemaJson string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &schemaJson))
+
+	if resp.Error != nil {
+		return
+	}
+
+	var collectionSchema collectionSchemaAPI
+	if err := json.Unmarshal([]byte(schemaJson), &collectionSchema); err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(0, fmt.Sprintf("Unable to parse collection schema json, got error: %s", err)))
+		return
+	}
+
+	for _, field := range collectionSchema.Fields {
+		if field.Name == "" || field.Type == "" {
+			resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(0, "Every field of the collection schema must have a name and a type"))
+			return
+		}
+	}
+
+	fields, diags := types.ListValueFrom(ctx, collectionFieldObjectType(ctx), flattenCollectionFields(collectionSchema.Fields))
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, function.FuncErrorFromDiags(ctx, diags))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, fields))
+}
+
+// collectionFieldObjectType returns the object type of a collection field,
+// taken from the resource schema so both always stay in sync.
+func collectionFieldObjectType(ctx context.Context) attr.Type {
+	schemaResp := &resource.SchemaResponse{}
+	(&CollectionResource{}).Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	return schemaResp.Schema.Blocks["fields"].GetNestedObject().Type()
+}
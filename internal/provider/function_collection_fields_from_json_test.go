@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccCollectionFieldsFromJsonFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("1.8.0"))),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCollectionFieldsFromJsonFunctionConfig("test_collection_from_json"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("field_count", "3"),
+					resource.TestCheckOutput("first_field_index", "true"),
+					resource.TestCheckResourceAttr("typesense_collection.test", "name", "test_collection_from_json"),
+					resource.TestCheckResourceAttr("typesense_collection.test", "fields.#", "3"),
+					resource.TestCheckTypeSetElemNestedAttrs("typesense_collection.test", "fields.*", map[string]string{
+						"name":  "category",
+						"type":  "string",
+						"facet": "true",
+					}),
+				),
+			},
+			// Applying the same schema again must not produce a diff
+			{
+				Config:   testAccCollectionFieldsFromJsonFunctionConfig("test_collection_from_json"),
+				PlanOnly: true,
+			},
+			{
+				Config: `
+output "test" {
+  value = provider::typesense::collection_fields_from_json(jsonencode({ fields = [{ name = "title" }] }))
+}
+`,
+				ExpectError: regexp.MustCompile(`must have a name and a type`),
+			},
+		},
+	})
+}
+
+func testAccCollectionFieldsFromJsonFunctionConfig(name string) string {
+	return fmt.Sprintf(`
+locals {
+  schema = jsonencode({
+    name = %[1]q
+    fields = [
+      { name = "title", type = "string" },
+      { name = "category", type = "string", facet = true },
+      { name = "rating", type = "int32", sort = true },
+    ]
+    default_sorting_field = "rating"
+  })
+
+  fields = provider::typesense::collection_fields_from_json(local.schema)
+}
+
+resource "typesense_collection" "test" {
+  name                  = jsondecode(local.schema).name
+  default_sorting_field = jsondecode(local.schema).default_sorting_field
+
+  dynamic "fields" {
+    for_each = local.fields
+
+    content {
+      name            = fields.value.name
+      type            = fields.value.type
+      facet           = fields.value.facet
+      index           = fields.value.index
+      optional        = fields.value.optional
+      sort            = fields.value.sort
+      infix           = fields.value.infix
+      stem            = fields.value.stem
+      stem_dictionary = fields.value.stem_dictionary
+      locale          = fields.value.locale
+      store           = fields.value.store
+      num_dim         = fields.value.num_dim
+    }
+  }
+}
+
+output "field_count" {
+  value = length(local.fields)
+}
+
+output "first_field_index" {
+  value = local.fields[0].index
+}
+`, name)
+}
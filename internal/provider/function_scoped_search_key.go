@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &ScopedSearchKeyFunction{}
+
+func NewScopedSearchKeyFunction() function.Function {
+	return &ScopedSearchKeyFunction{}
+}
+
+type ScopedSearchKeyFunction struct{}
+
+func (f *ScopedSearchKeyFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "scoped_search_key"
+}
+
+func (f *ScopedSearchKeyFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Generate a scoped search key",
+		MarkdownDescription: "Generates a scoped search key from a parent search-only key and a JSON object of embedded search parameters (e.g. `filter_by`, `expires_at`). The key is computed locally with HMAC-SHA256, no request is sent to the Typesense server.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "parent_key",
+				MarkdownDescription: "Search-only API key the scoped key is derived from",
+			},
+			function.StringParameter{
+				Name:                "params_json",
+				MarkdownDescription: "JSON object with the search parameters to embed, e.g. `jsonencode({ filter_by = \"tenant_id:42\" })`",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *ScopedSearchKeyFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var parentKey string
+	var paramsJson string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &parentKey, &paramsJson))
+
+	if resp.Error != nil {
+		return
+	}
+
+	scopedKey, err := generateScopedSearchKey(parentKey, paramsJson)
+
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewFuncError(err.Error()))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, scopedKey))
+}
+
+// generateScopedSearchKey embeds the search parameters into a key signed with
+// the parent key, using the same algorithm as the official Typesense clients:
+// base64(base64(hmac_sha256(parent_key, params)) + parent_key[0:4] + params)
+func generateScopedSearchKey(parentKey string, paramsJson string) (string, error) {
+	if len(parentKey) < 4 {
+		return "", fmt.Errorf("parent key must be at least 4 characters long")
+	}
+
+	var params map[string]interface{}
+	if err := json.Unmarshal([]byte(paramsJson), &params); err != nil || params == nil {
+		return "", fmt.Errorf("search parameters must be a JSON object")
+	}
+
+	compacted := &bytes.Buffer{}
+	if err := json.Compact(compacted, []byte(paramsJson)); err != nil {
+		return "", err
+	}
+
+	mac := hmac.New(sha256.New, []byte(parentKey))
+	mac.Write(compacted.Bytes())
+
+	digest := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	rawScopedKey := digest + parentKey[0:4] + compacted.String()
+
+	return base64.StdEncoding.EncodeToString([]byte(rawScopedKey)), nil
+}
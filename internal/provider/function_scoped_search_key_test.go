@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+// Example key and parameters from the Typesense documentation.
+const (
+	testScopedSearchParentKey = "RN23GFr1s6jQ9kgSNg2O7fYcAUXU7127"
+	testScopedSearchKey       = "SC9sT0hncHFwTHNFc3U3d3psRDZBUGNXQUViQUdDNmRHSmJFQnNnczJ4VT1STjIzeyJmaWx0ZXJfYnkiOiJjb21wYW55X2lkOjEyNCJ9"
+)
+
+func TestGenerateScopedSearchKey(t *testing.T) {
+	scopedKey, err := generateScopedSearchKey(testScopedSearchParentKey, `{ "filter_by": "company_id:124" }`)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if scopedKey != testScopedSearchKey {
+		t.Errorf("expected %s, got %s", testScopedSearchKey, scopedKey)
+	}
+
+	if _, err := generateScopedSearchKey(testScopedSearchParentKey, `["company_id:124"]`); err == nil {
+		t.Error("expected an error for non-object search parameters")
+	}
+
+	if _, err := generateScopedSearchKey("abc", `{}`); err == nil {
+		t.Error("expected an error for a parent key shorter than 4 characters")
+	}
+}
+
+func TestAccScopedSearchKeyFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("1.8.0"))),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "test" {
+  value = provider::typesense::scoped_search_key("` + testScopedSearchParentKey + `", jsonencode({ filter_by = "company_id:124" }))
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("test", testScopedSearchKey),
+				),
+			},
+			{
+				Config: `
+output "test" {
+  value = provider::typesense::scoped_search_key("` + testScopedSearchParentKey + `", "company_id:124")
+}
+`,
+				ExpectError: regexp.MustCompile(`search parameters must be a JSON object`),
+			},
+		},
+	})
+}
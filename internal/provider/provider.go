@@ -2,8 +2,8 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"os"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/function"
@@ -12,8 +12,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-
-	"github.com/typesense/typesense-go/v3/typesense"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -118,17 +116,20 @@ func (p *TypesenseProvider) Configure(ctx context.Context, req provider.Configur
 	}
 
 	// Create a new typesense client using the configuration values
-	client := typesense.NewClient(
-		typesense.WithServer(api_address),
-		typesense.WithAPIKey(api_key),
-		typesense.WithConnectionTimeout(30*time.Second),
-		typesense.WithCircuitBreakerMaxRequests(50),
-		typesense.WithCircuitBreakerInterval(2*time.Minute),
-		typesense.WithCircuitBreakerTimeout(1*time.Minute))
+	client, err := newTypesenseClient(api_address, api_key)
+
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("api_address"),
+			"Invalid Typesense API Address",
+			fmt.Sprintf("The provider cannot create the Typesense API client, got error: %s", err),
+		)
+		return
+	}
 
 	// Make the Typesense client available during DataSource and Resource
 	// type Configure methods.
-	resp.DataSourceData = client
+	resp.DataSourceData = client.Client
 	resp.ResourceData = client
 }
 
@@ -140,15 +141,22 @@ func (p *TypesenseProvider) Resources(_ context.Context) []func() resource.Resou
 		NewDocumentResource,
 		NewAliasResource,
 		NewApiKeyResource,
+		NewScopedKeyResource,
 	}
 }
 
 // DataSources defines the data sources implemented in the provider.
 func (p *TypesenseProvider) DataSources(_ context.Context) []func() datasource.DataSource {
-	return nil
+	return []func() datasource.DataSource{
+		NewDocumentDataSource,
+		NewSearchDataSource,
+	}
 }
 
 // Functions implements provider.ProviderWithFunctions.
 func (p *TypesenseProvider) Functions(context.Context) []func() function.Function {
-	return nil
+	return []func() function.Function{
+		NewScopedSearchKeyFunction,
+		NewCollectionFieldsFromJsonFunction,
+	}
 }
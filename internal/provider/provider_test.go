@@ -4,11 +4,16 @@
 package provider
 
 import (
+	"context"
 	"os"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
@@ -39,3 +44,72 @@ func testAccPreCheck(t *testing.T) {
 		t.Fatal("TYPESENSE_API_ADDRESS must be set for acceptance tests")
 	}
 }
+
+// testUpgradeResourceState runs the state upgrader of the given version on a
+// raw JSON state, the same way Terraform does for states written by older
+// provider releases, and returns the upgraded state.
+func testUpgradeResourceState(t *testing.T, r resource.ResourceWithUpgradeState, version int64, rawState string) tfsdk.State {
+	t.Helper()
+
+	ctx := context.Background()
+
+	upgrader, ok := r.UpgradeState(ctx)[version]
+	if !ok {
+		t.Fatalf("no state upgrader for version %d", version)
+	}
+
+	priorType := upgrader.PriorSchema.Type().TerraformType(ctx)
+	priorValue, err := (&tfprotov6.RawState{JSON: []byte(rawState)}).Unmarshal(priorType)
+	if err != nil {
+		t.Fatalf("unable to decode raw state: %s", err)
+	}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	req := resource.UpgradeStateRequest{
+		State: &tfsdk.State{Schema: *upgrader.PriorSchema, Raw: priorValue},
+	}
+	resp := &resource.UpgradeStateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)},
+	}
+
+	upgrader.StateUpgrader(ctx, req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unable to upgrade state: %v", resp.Diagnostics)
+	}
+
+	return resp.State
+}
+
+// testResourceValue decodes a JSON object into a value of the resource schema,
+// attributes left out are null, and marks the values at the given paths as
+// unknown, as Terraform does for values not known until apply.
+func testResourceValue(t *testing.T, r resource.Resource, rawValue string, unknown ...*tftypes.AttributePath) (schema.Schema, tftypes.Value) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	value, err := tftypes.ValueFromJSON([]byte(rawValue), schemaResp.Schema.Type().TerraformType(ctx))
+	if err != nil {
+		t.Fatalf("unable to decode value: %s", err)
+	}
+
+	value, err = tftypes.Transform(value, func(p *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
+		for _, u := range unknown {
+			if p.Equal(u) {
+				return tftypes.NewValue(v.Type(), tftypes.UnknownValue), nil
+			}
+		}
+		return v, nil
+	})
+	if err != nil {
+		t.Fatalf("unable to mark unknown values: %s", err)
+	}
+
+	return schemaResp.Schema, value
+}
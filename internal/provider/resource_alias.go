@@ -20,6 +20,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &AliasResource{}
 var _ resource.ResourceWithImportState = &AliasResource{}
+var _ resource.ResourceWithUpgradeState = &AliasResource{}
 
 func NewAliasResource() resource.Resource {
 	return &AliasResource{}
@@ -35,12 +36,20 @@ type AliasResourceModel struct {
 	CollectionName types.String `tfsdk:"collection_name"`
 }
 
+// aliasResourceModelV0 is the state of schema version 0.
+type aliasResourceModelV0 struct {
+	Id             types.String `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	CollectionName types.String `tfsdk:"collection_name"`
+}
+
 func (r *AliasResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_alias"
 }
 
 func (r *AliasResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:             1,
 		MarkdownDescription: "An alias is a virtual collection name that points to a real collection. If you're familiar with symbolic links on Linux, it's very similar to that.",
 
 		Attributes: map[string]schema.Attribute{
@@ -75,7 +84,7 @@ func (r *AliasResource) Configure(ctx context.Context, req resource.ConfigureReq
 		return
 	}
 
-	client, ok := req.ProviderData.(*typesense.Client)
+	client, ok := req.ProviderData.(*typesenseClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
@@ -86,7 +95,7 @@ func (r *AliasResource) Configure(ctx context.Context, req resource.ConfigureReq
 		return
 	}
 
-	r.client = client
+	r.client = client.Client
 }
 
 func (r *AliasResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -194,6 +203,37 @@ func (r *AliasResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	data.Id = types.StringValue("")
 }
 
+func (r *AliasResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"id":              schema.StringAttribute{Computed: true},
+					"name":            schema.StringAttribute{Required: true},
+					"collection_name": schema.StringAttribute{Required: true},
+				},
+			},
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior aliasResourceModelV0
+
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				data := AliasResourceModel{
+					Id:             prior.Id,
+					Name:           prior.Name,
+					CollectionName: prior.CollectionName,
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			},
+		},
+	}
+}
+
 func (r *AliasResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
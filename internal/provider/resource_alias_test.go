@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -119,3 +120,16 @@ resource "typesense_alias" "test2" {
 }
 `, collectionName, alias1Name, alias2Name)
 }
+
+func TestAliasResource_UpgradeStateV0(t *testing.T) {
+	state := testUpgradeResourceState(t, &AliasResource{}, 0, `{"id": "products", "name": "products", "collection_name": "products_v1"}`)
+
+	var data AliasResourceModel
+	if diags := state.Get(context.Background(), &data); diags.HasError() {
+		t.Fatalf("unable to read upgraded state: %v", diags)
+	}
+
+	if data.Id.ValueString() != "products" || data.Name.ValueString() != "products" || data.CollectionName.ValueString() != "products_v1" {
+		t.Errorf("expected alias state to be kept, got %+v", data)
+	}
+}
@@ -22,6 +22,7 @@ import (
 
 var _ resource.Resource = &ApiKeyResource{}
 var _ resource.ResourceWithImportState = &ApiKeyResource{}
+var _ resource.ResourceWithUpgradeState = &ApiKeyResource{}
 
 func NewApiKeyResource() resource.Resource {
 	return &ApiKeyResource{}
@@ -41,12 +42,24 @@ type ApiKeyResourceModel struct {
 	ValuePrefix types.String   `tfsdk:"value_prefix"`
 }
 
+// apiKeyResourceModelV0 is the state of schema version 0.
+type apiKeyResourceModelV0 struct {
+	Id          types.String   `tfsdk:"id"`
+	Description types.String   `tfsdk:"description"`
+	Actions     []types.String `tfsdk:"actions"`
+	Collections []types.String `tfsdk:"collections"`
+	ExpiresAt   types.Int64    `tfsdk:"expires_at"`
+	Value       types.String   `tfsdk:"value"`
+	ValuePrefix types.String   `tfsdk:"value_prefix"`
+}
+
 func (r *ApiKeyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_api_key"
 }
 
 func (r *ApiKeyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:             1,
 		MarkdownDescription: "API Key resource for accessing Typesense collections with specific permissions",
 
 		Attributes: map[string]schema.Attribute{
@@ -112,7 +125,7 @@ func (r *ApiKeyResource) Configure(ctx context.Context, req resource.ConfigureRe
 		return
 	}
 
-	client, ok := req.ProviderData.(*typesense.Client)
+	client, ok := req.ProviderData.(*typesenseClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
@@ -122,7 +135,7 @@ func (r *ApiKeyResource) Configure(ctx context.Context, req resource.ConfigureRe
 		return
 	}
 
-	r.client = client
+	r.client = client.Client
 }
 
 func (r *ApiKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -293,6 +306,45 @@ func (r *ApiKeyResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	data.Id = types.StringValue("")
 }
 
+func (r *ApiKeyResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"id":           schema.StringAttribute{Computed: true},
+					"description":  schema.StringAttribute{Required: true},
+					"actions":      schema.ListAttribute{Required: true, ElementType: types.StringType},
+					"collections":  schema.ListAttribute{Required: true, ElementType: types.StringType},
+					"expires_at":   schema.Int64Attribute{Optional: true},
+					"value":        schema.StringAttribute{Optional: true, Computed: true, Sensitive: true},
+					"value_prefix": schema.StringAttribute{Computed: true},
+				},
+			},
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior apiKeyResourceModelV0
+
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				data := ApiKeyResourceModel{
+					Id:          prior.Id,
+					Description: prior.Description,
+					Actions:     prior.Actions,
+					Collections: prior.Collections,
+					ExpiresAt:   prior.ExpiresAt,
+					Value:       prior.Value,
+					ValuePrefix: prior.ValuePrefix,
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			},
+		},
+	}
+}
+
 func (r *ApiKeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
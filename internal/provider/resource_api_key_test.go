@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -138,3 +139,27 @@ resource "typesense_api_key" "test" {
 }
 `, name, description)
 }
+
+func TestApiKeyResource_UpgradeStateV0(t *testing.T) {
+	state := testUpgradeResourceState(t, &ApiKeyResource{}, 0, `{
+  "id": "42",
+  "description": "Search only key",
+  "actions": ["documents:search"],
+  "collections": ["products"],
+  "expires_at": null,
+  "value": "abcd1234",
+  "value_prefix": "abcd"
+}`)
+
+	var data ApiKeyResourceModel
+	if diags := state.Get(context.Background(), &data); diags.HasError() {
+		t.Fatalf("unable to read upgraded state: %v", diags)
+	}
+
+	if data.Id.ValueString() != "42" || data.Value.ValueString() != "abcd1234" || data.ValuePrefix.ValueString() != "abcd" {
+		t.Errorf("expected api key state to be kept, got %+v", data)
+	}
+	if len(data.Actions) != 1 || len(data.Collections) != 1 || !data.ExpiresAt.IsNull() {
+		t.Errorf("expected api key permissions to be kept, got %+v", data)
+	}
+}
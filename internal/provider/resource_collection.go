@@ -4,23 +4,34 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"regexp"
+	"slices"
+	"sort"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
-	"github.com/typesense/typesense-go/v3/typesense"
 	"github.com/typesense/typesense-go/v3/typesense/api"
 )
 
@@ -28,40 +39,66 @@ import (
 var _ resource.Resource = &CollectionResource{}
 var _ resource.ResourceWithImportState = &CollectionResource{}
 var _ resource.ResourceWithModifyPlan = &CollectionResource{}
+var _ resource.ResourceWithUpgradeState = &CollectionResource{}
+var _ resource.ResourceWithValidateConfig = &CollectionResource{}
 
 func NewCollectionResource() resource.Resource {
 	return &CollectionResource{}
 }
 
 type CollectionResource struct {
-	client *typesense.Client
+	client *typesenseClient
 }
 
 type CollectionResourceModel struct {
-	Id                  types.String                   `tfsdk:"id"`
-	Name                types.String                   `tfsdk:"name"`
-	DefaultSortingField types.String                   `tfsdk:"default_sorting_field"`
-	Fields              []CollectionResourceFieldModel `tfsdk:"fields"`
-	EnableNestedFields  types.Bool                     `tfsdk:"enable_nested_fields"`
-	SymbolsToIndex      []types.String                 `tfsdk:"symbols_to_index"`
-	TokenSeparators     []types.String                 `tfsdk:"token_separators"`
-	DeletionProtection  types.Bool                     `tfsdk:"deletion_protection"`
+	Id                  types.String                    `tfsdk:"id"`
+	Name                types.String                    `tfsdk:"name"`
+	DefaultSortingField types.String                    `tfsdk:"default_sorting_field"`
+	Fields              []CollectionResourceFieldModel  `tfsdk:"fields"`
+	EnableNestedFields  types.Bool                      `tfsdk:"enable_nested_fields"`
+	SymbolsToIndex      []types.String                  `tfsdk:"symbols_to_index"`
+	TokenSeparators     []types.String                  `tfsdk:"token_separators"`
+	DeletionProtection  types.Bool                      `tfsdk:"deletion_protection"`
+	Metadata            jsontypes.Normalized            `tfsdk:"metadata"`
+	FieldChangeStrategy types.String                    `tfsdk:"field_change_strategy"`
+	PhysicalName        types.String                    `tfsdk:"physical_name"`
+	ManagedFields       types.String                    `tfsdk:"managed_fields"`
+	BackupOnDestroy     *CollectionBackupOnDestroyModel `tfsdk:"backup_on_destroy"`
+	NumDocuments        types.Int64                     `tfsdk:"num_documents"`
+	CreatedAt           types.Int64                     `tfsdk:"created_at"`
+	TruncateOn          types.Map                       `tfsdk:"truncate_on"`
+	SourceCollection    types.String                    `tfsdk:"source_collection"`
+	CopySynonyms        types.Bool                      `tfsdk:"copy_synonyms"`
+	CopyOverrides       types.Bool                      `tfsdk:"copy_overrides"`
+	Timeouts            timeouts.Value                  `tfsdk:"timeouts"`
+}
+
+type CollectionBackupOnDestroyModel struct {
+	Path types.String `tfsdk:"path"`
+	Gzip types.Bool   `tfsdk:"gzip"`
 }
 
 type CollectionResourceFieldModel struct {
-	Name           types.String               `tfsdk:"name"`
-	Facet          types.Bool                 `tfsdk:"facet"`
-	Index          types.Bool                 `tfsdk:"index"`
-	Optional       types.Bool                 `tfsdk:"optional"`
-	Sort           types.Bool                 `tfsdk:"sort"`
-	Infix          types.Bool                 `tfsdk:"infix"`
-	Type           types.String               `tfsdk:"type"`
-	Stem           types.Bool                 `tfsdk:"stem"`
-	StemDictionary types.String               `tfsdk:"stem_dictionary"`
-	Locale         types.String               `tfsdk:"locale"`
-	Store          types.Bool                 `tfsdk:"store"`
-	NumDim         types.Int64                `tfsdk:"num_dim"`
-	Embed          *CollectionFieldEmbedModel `tfsdk:"embed"`
+	Name            types.String               `tfsdk:"name"`
+	Facet           types.Bool                 `tfsdk:"facet"`
+	Index           types.Bool                 `tfsdk:"index"`
+	Optional        types.Bool                 `tfsdk:"optional"`
+	Sort            types.Bool                 `tfsdk:"sort"`
+	Infix           types.Bool                 `tfsdk:"infix"`
+	Type            types.String               `tfsdk:"type"`
+	Stem            types.Bool                 `tfsdk:"stem"`
+	StemDictionary  types.String               `tfsdk:"stem_dictionary"`
+	Locale          types.String               `tfsdk:"locale"`
+	Store           types.Bool                 `tfsdk:"store"`
+	RangeIndex      types.Bool                 `tfsdk:"range_index"`
+	TokenSeparators types.List                 `tfsdk:"token_separators"`
+	SymbolsToIndex  types.List                 `tfsdk:"symbols_to_index"`
+	NumDim          types.Int64                `tfsdk:"num_dim"`
+	VecDist         types.String               `tfsdk:"vec_dist"`
+	HnswParams      types.Object               `tfsdk:"hnsw_params"`
+	Reference       types.String               `tfsdk:"reference"`
+	AsyncReference  types.Bool                 `tfsdk:"async_reference"`
+	Embed           *CollectionFieldEmbedModel `tfsdk:"embed"`
 }
 
 type CollectionFieldEmbedModel struct {
@@ -82,6 +119,41 @@ type CollectionFieldEmbedModelConfigModel struct {
 	RefreshToken   types.String `tfsdk:"refresh_token"`
 }
 
+// Strategies for applying changes to existing fields of a collection.
+const (
+	fieldChangeStrategyInPlace            = "in_place"
+	fieldChangeStrategyFail               = "fail"
+	fieldChangeStrategyRecreateCollection = "recreate_collection"
+	fieldChangeStrategyReindexViaAlias    = "reindex_via_alias"
+)
+
+// Which fields of a collection are managed by Terraform.
+const (
+	managedFieldsAll          = "all"
+	managedFieldsDeclaredOnly = "declared_only"
+)
+
+// Defaults applied by Typesense to the HNSW index of vector fields.
+const (
+	defaultVecDist            = "cosine"
+	defaultHnswM              = 16
+	defaultHnswEfConstruction = 200
+)
+
+// Default timeouts of collection operations. Schema changes block until all
+// documents are re-indexed, which takes minutes on large collections.
+const (
+	defaultCollectionCreateTimeout = 20 * time.Minute
+	defaultCollectionReadTimeout   = 5 * time.Minute
+	defaultCollectionUpdateTimeout = 60 * time.Minute
+	defaultCollectionDeleteTimeout = 20 * time.Minute
+)
+
+var fieldHnswParamsAttrTypes = map[string]attr.Type{
+	"m":               types.Int64Type,
+	"ef_construction": types.Int64Type,
+}
+
 // fieldEmbedAPI mirrors the inline embed struct on api.Field.
 type fieldEmbedAPI = struct {
 	From        []string `json:"from"`
@@ -105,7 +177,8 @@ func (r *CollectionResource) Metadata(ctx context.Context, req resource.Metadata
 
 func (r *CollectionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Group of related documents which are roughly equivalent to a table in a relational database. Terraform will still remove auto-created fields for collections with auto-type, so you need to manually update the collection schema to match generated fields",
+		Version:             1,
+		MarkdownDescription: "Group of related documents which are roughly equivalent to a table in a relational database. By default Terraform removes the fields Typesense auto-creates for pattern fields, like `.*` with type `auto`, set `managed_fields` to `declared_only` to leave them alone",
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -116,17 +189,17 @@ func (r *CollectionResource) Schema(ctx context.Context, req resource.SchemaRequ
 				},
 			},
 			"name": schema.StringAttribute{
-				MarkdownDescription: "Collection name",
+				MarkdownDescription: "Collection name. Changing it recreates the collection, unless `field_change_strategy` is `reindex_via_alias`.",
 				Required:            true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.RequiresReplaceIf(requiresReplaceUnlessReindexViaAliasString, requiresReplaceUnlessReindexViaAliasDescription, requiresReplaceUnlessReindexViaAliasDescription),
 				},
 			},
 			"default_sorting_field": schema.StringAttribute{
 				Optional:            true,
-				MarkdownDescription: "Default sorting field",
+				MarkdownDescription: "Default sorting field. Changing it recreates the collection, unless `field_change_strategy` is `reindex_via_alias`.",
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.RequiresReplaceIf(requiresReplaceUnlessReindexViaAliasString, requiresReplaceUnlessReindexViaAliasDescription, requiresReplaceUnlessReindexViaAliasDescription),
 				},
 			},
 			"enable_nested_fields": schema.BoolAttribute{
@@ -135,24 +208,60 @@ func (r *CollectionResource) Schema(ctx context.Context, req resource.SchemaRequ
 				MarkdownDescription: "Enable nested fields, must be enabled to use object/object[] types",
 				Default:             booldefault.StaticBool(false),
 			},
+			"metadata": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Custom metadata object of the collection in JSON format, e.g. ownership or schema version. Updated in place.",
+				CustomType:          jsontypes.NormalizedType{},
+			},
+			"source_collection": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Name of an existing collection whose schema is cloned into this one, without its documents. Declared fields are added to the cloned ones, or replace them when they have the same name, and only declared fields are read and updated. " +
+					"Settings that cannot be altered, like `default_sorting_field`, must match the source collection. Cannot be used with the `reindex_via_alias` field change strategy. Changing it recreates the collection.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"copy_synonyms": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Whether the synonyms of `source_collection` are copied when the collection is created. Defaults to `true`.",
+				Default:             booldefault.StaticBool(true),
+				Validators: []validator.Bool{
+					boolvalidator.AlsoRequires(path.MatchRoot("source_collection")),
+				},
+			},
+			"copy_overrides": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Whether the overrides of `source_collection` are copied when the collection is created. Defaults to `true`.",
+				Default:             booldefault.StaticBool(true),
+				Validators: []validator.Bool{
+					boolvalidator.AlsoRequires(path.MatchRoot("source_collection")),
+				},
+			},
+			"truncate_on": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Arbitrary map of values that, when changed, deletes all documents of the collection. The schema, aliases and other settings are kept. Setting it for the first time or removing it deletes nothing. Use it to empty a collection before seeding it again.",
+			},
 			"symbols_to_index": schema.ListAttribute{
 				ElementType:         types.StringType,
 				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "List of symbols to index",
+				MarkdownDescription: "List of symbols to index. Changing it recreates the collection, unless `field_change_strategy` is `reindex_via_alias`.",
 				Default:             listdefault.StaticValue(types.ListValueMust(types.StringType, []attr.Value{})),
 				PlanModifiers: []planmodifier.List{
-					listplanmodifier.RequiresReplace(),
+					listplanmodifier.RequiresReplaceIf(requiresReplaceUnlessReindexViaAliasList, requiresReplaceUnlessReindexViaAliasDescription, requiresReplaceUnlessReindexViaAliasDescription),
 				},
 			},
 			"token_separators": schema.ListAttribute{
 				ElementType:         types.StringType,
 				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "List of token separators",
+				MarkdownDescription: "List of token separators. Changing it recreates the collection, unless `field_change_strategy` is `reindex_via_alias`.",
 				Default:             listdefault.StaticValue(types.ListValueMust(types.StringType, []attr.Value{})),
 				PlanModifiers: []planmodifier.List{
-					listplanmodifier.RequiresReplace(),
+					listplanmodifier.RequiresReplaceIf(requiresReplaceUnlessReindexViaAliasList, requiresReplaceUnlessReindexViaAliasDescription, requiresReplaceUnlessReindexViaAliasDescription),
 				},
 			},
 			"deletion_protection": schema.BoolAttribute{
@@ -161,13 +270,88 @@ func (r *CollectionResource) Schema(ctx context.Context, req resource.SchemaRequ
 				MarkdownDescription: "Whether or not to allow Terraform to destroy the collection. Unless this field is set to false in Terraform state, a terraform destroy or terraform apply that would delete the collection will fail.",
 				Default:             booldefault.StaticBool(false),
 			},
+			"field_change_strategy": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				MarkdownDescription: "How changes to existing fields are applied. `in_place` drops and re-adds the changed fields in the same collection, `fail` rejects the plan, " +
+					"`recreate_collection` destroys and creates the collection again, losing its documents, and `reindex_via_alias` copies the documents, synonyms and overrides into a new versioned " +
+					"collection (`<name>_v2`, `<name>_v3`, ...) served through an alias named `name`. Documents written while they are copied only reach the previous version and are lost, stop writes during the apply. " +
+					"The previous version is then backed up following `backup_on_destroy` and deleted, unless `deletion_protection` is set. Switching to or from `reindex_via_alias` recreates the collection. Defaults to `in_place`.",
+				Default: stringdefault.StaticString(fieldChangeStrategyInPlace),
+				Validators: []validator.String{
+					stringvalidator.OneOf(fieldChangeStrategyInPlace, fieldChangeStrategyFail, fieldChangeStrategyRecreateCollection, fieldChangeStrategyReindexViaAlias),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+							resp.RequiresReplace = (req.StateValue.ValueString() == fieldChangeStrategyReindexViaAlias) != (req.PlanValue.ValueString() == fieldChangeStrategyReindexViaAlias)
+						},
+						"Switching to or from reindex_via_alias recreates the collection.",
+						"Switching to or from `reindex_via_alias` recreates the collection.",
+					),
+				},
+			},
+			"managed_fields": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				MarkdownDescription: "Fields managed by Terraform. With `all`, all fields of the collection are read, and the ones missing from the configuration are dropped, including the fields Typesense auto-creates for declared pattern fields, like `.*` with type `auto`. " +
+					"With `declared_only`, the fields auto-created for declared pattern fields are neither read nor dropped, other fields missing from the configuration are still dropped. Defaults to `all`.",
+				Default: stringdefault.StaticString(managedFieldsAll),
+				Validators: []validator.String{
+					stringvalidator.OneOf(managedFieldsAll, managedFieldsDeclaredOnly),
+				},
+			},
+			"physical_name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Name of the underlying collection, which differs from `name` when `field_change_strategy` is `reindex_via_alias`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"num_documents": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of documents in the collection when it was last read. Documents indexed outside Terraform show up on the next refresh, not as a diff.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_at": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Creation time of the collection, as a Unix timestamp in seconds",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
 		},
 		Blocks: map[string]schema.Block{
-			"fields": schema.SetNestedBlock{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+			"backup_on_destroy": schema.SingleNestedBlock{
+				MarkdownDescription: "Export all documents to a local JSONL file before the collection is destroyed or replaced, or before the previous version is deleted by a `reindex_via_alias` change. The destroy fails if the export fails.",
+				Attributes: map[string]schema.Attribute{
+					"path": schema.StringAttribute{
+						Required:            true,
+						MarkdownDescription: "Path of the backup file, overwritten if it exists",
+					},
+					"gzip": schema.BoolAttribute{
+						Optional:            true,
+						MarkdownDescription: "Compress the backup file with gzip. Defaults to false.",
+					},
+				},
+			},
+			"fields": schema.ListNestedBlock{
+				MarkdownDescription: "Collection fields. The plan compares fields by their position in the list, so adding or removing a field before others, or reordering fields, shows the following fields as changed. " +
+					"Fields are matched by name when applying, only the fields actually added, removed or modified are altered in Typesense. " +
+					"States written before fields were a list keep their stored order, the first apply after the upgrade may show such a reordering, it changes nothing in Typesense.",
 				NestedObject: schema.NestedBlockObject{
 					Attributes: map[string]schema.Attribute{
 						"name": schema.StringAttribute{
-							Required: true,
+							Required:    true,
+							Description: "Field name. A regular expression, e.g. .* or .*_facet, declares the attributes of all matching fields, the fields Typesense creates for it are only tracked with managed_fields all. The .* pattern only supports the auto and string* types.",
 						},
 						"facet": schema.BoolAttribute{
 							Optional:    true,
@@ -182,7 +366,7 @@ func (r *CollectionResource) Schema(ctx context.Context, req resource.SchemaRequ
 						"optional": schema.BoolAttribute{
 							Optional:    true,
 							Computed:    true,
-							Description: "Optional field. Defaults to false.",
+							Description: "Optional field. Defaults to false, or true for pattern fields.",
 						},
 						"sort": schema.BoolAttribute{
 							Optional:    true,
@@ -239,10 +423,61 @@ func (r *CollectionResource) Schema(ctx context.Context, req resource.SchemaRequ
 							Computed:    true,
 							Description: "Store field value on disk. Defaults to true.",
 						},
+						"range_index": schema.BoolAttribute{
+							Optional:    true,
+							Computed:    true,
+							Description: "Enable an index optimized for range filtering on numerical fields. Defaults to false.",
+						},
+						"token_separators": schema.ListAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+							Computed:    true,
+							Description: "List of token separators for this field, in addition to the collection ones. Defaults to an empty list.",
+						},
+						"symbols_to_index": schema.ListAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+							Computed:    true,
+							Description: "List of symbols to index for this field, in addition to the collection ones. Defaults to an empty list.",
+						},
 						"num_dim": schema.Int64Attribute{
 							Optional:    true,
 							Description: "Number of dimensions for vector fields (float[] type). Required for vector search.",
 						},
+						"vec_dist": schema.StringAttribute{
+							Optional:    true,
+							Computed:    true,
+							Description: "Distance metric used for vector search, cosine or ip (inner product). Only for float[] fields with num_dim or embed. Defaults to cosine.",
+							Validators: []validator.String{
+								stringvalidator.OneOf("cosine", "ip"),
+							},
+						},
+						"hnsw_params": schema.SingleNestedAttribute{
+							Optional:    true,
+							Computed:    true,
+							Description: "Parameters of the HNSW index. Only for float[] fields with num_dim or embed.",
+							Attributes: map[string]schema.Attribute{
+								"m": schema.Int64Attribute{
+									Optional:    true,
+									Computed:    true,
+									Description: "Maximum number of connections per node (M). Defaults to 16.",
+								},
+								"ef_construction": schema.Int64Attribute{
+									Optional:    true,
+									Computed:    true,
+									Description: "Size of the candidate list used while building the index. Defaults to 200.",
+								},
+							},
+						},
+						"reference": schema.StringAttribute{
+							Optional:    true,
+							Description: "Field of another collection this field references for joins, in the `collection.field` format.",
+						},
+						"async_reference": schema.BoolAttribute{
+							Optional:    true,
+							Computed:    true,
+							Description: "Allow indexing documents before the referenced document exists. Requires reference. Defaults to false.",
+						},
 					},
 					Blocks: map[string]schema.Block{
 						"embed": schema.SingleNestedBlock{
@@ -258,7 +493,7 @@ func (r *CollectionResource) Schema(ctx context.Context, req resource.SchemaRequ
 									Attributes: map[string]schema.Attribute{
 										"model_name": schema.StringAttribute{
 											Optional:    true,
-											Description: "Model name for embedding generation (e.g. ts/clip-vit-b-p32)",
+											Description: "Model name for embedding generation, prefixed with its provider: ts/, openai/, google/, gcp/ or azure/ (e.g. ts/clip-vit-b-p32)",
 										},
 										"url": schema.StringAttribute{
 											Optional:    true,
@@ -267,15 +502,13 @@ func (r *CollectionResource) Schema(ctx context.Context, req resource.SchemaRequ
 										},
 										"access_token": schema.StringAttribute{
 											Optional:    true,
-											Computed:    true,
 											Sensitive:   true,
-											Description: "Access token for authentication",
+											Description: "Access token for authentication. Never read back from Typesense, changes made outside Terraform are not detected. Changing it updates the model credentials without embedding the documents again.",
 										},
 										"api_key": schema.StringAttribute{
 											Optional:    true,
-											Computed:    true,
 											Sensitive:   true,
-											Description: "API key for authentication",
+											Description: "API key for authentication. Never read back from Typesense, changes made outside Terraform are not detected. Changing it updates the model credentials without embedding the documents again.",
 										},
 										"client_id": schema.StringAttribute{
 											Optional:    true,
@@ -284,9 +517,8 @@ func (r *CollectionResource) Schema(ctx context.Context, req resource.SchemaRequ
 										},
 										"client_secret": schema.StringAttribute{
 											Optional:    true,
-											Computed:    true,
 											Sensitive:   true,
-											Description: "Client secret for OAuth",
+											Description: "Client secret for OAuth. Never read back from Typesense, changes made outside Terraform are not detected. Changing it updates the model credentials without embedding the documents again.",
 										},
 										"indexing_prefix": schema.StringAttribute{
 											Optional:    true,
@@ -305,9 +537,8 @@ func (r *CollectionResource) Schema(ctx context.Context, req resource.SchemaRequ
 										},
 										"refresh_token": schema.StringAttribute{
 											Optional:    true,
-											Computed:    true,
 											Sensitive:   true,
-											Description: "Refresh token for OAuth",
+											Description: "Refresh token for OAuth. Never read back from Typesense, changes made outside Terraform are not detected. Changing it updates the model credentials without embedding the documents again.",
 										},
 									},
 								},
@@ -326,7 +557,7 @@ func (r *CollectionResource) Configure(ctx context.Context, req resource.Configu
 		return
 	}
 
-	client, ok := req.ProviderData.(*typesense.Client)
+	client, ok := req.ProviderData.(*typesenseClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
@@ -350,59 +581,67 @@ func (r *CollectionResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
-	schema := &api.CollectionSchema{}
-	schema.Name = data.Name.ValueString()
-	schema.DefaultSortingField = data.DefaultSortingField.ValueStringPointer()
-	schema.EnableNestedFields = data.EnableNestedFields.ValueBoolPointer()
-
-	symbolsToIndex := []string{}
-	for _, symbol := range data.SymbolsToIndex {
-		symbolsToIndex = append(symbolsToIndex, symbol.ValueString())
-	}
-	schema.SymbolsToIndex = &symbolsToIndex
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCollectionCreateTimeout)
+	resp.Diagnostics.Append(diags...)
 
-	tokensSeparators := []string{}
-	for _, token := range data.TokenSeparators {
-		tokensSeparators = append(tokensSeparators, token.ValueString())
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	schema.TokenSeparators = &tokensSeparators
 
-	fields := []api.Field{}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
 
-	for _, field := range data.Fields {
-		fields = append(fields, filedModelToApiField(field))
+	physicalName := data.Name.ValueString()
+	if isReindexViaAlias(data) {
+		physicalName = physicalCollectionName(data.Name.ValueString(), 1)
 	}
 
-	schema.Fields = fields
-	collection, err := r.client.Collections().Create(ctx, schema)
+	schema, err := collectionModelToSchema(data, physicalName)
 
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create collection, got error: %s", err))
+		resp.Diagnostics.AddError("JSON format error", fmt.Sprintf("Unable to parse collection metadata, got error: %s", err))
 		return
 	}
 
-	data.Id = types.StringValue(collection.Name)
-	data.Name = types.StringValue(collection.Name)
+	var collection *collectionResponseAPI
 
-	if collection.DefaultSortingField != nil && *collection.DefaultSortingField != "" {
-		data.DefaultSortingField = types.StringPointerValue(collection.DefaultSortingField)
-	}
+	if data.SourceCollection.IsNull() {
+		collection, err = r.client.createCollection(ctx, schema)
 
-	data.EnableNestedFields = types.BoolPointerValue(collection.EnableNestedFields)
-	data.Fields = flattenCollectionFields(collection.Fields)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create collection, got error: %s", err))
+			return
+		}
+	} else {
+		collection = r.cloneCollection(ctx, data, physicalName, &resp.Diagnostics)
 
-	data.SymbolsToIndex = []types.String{}
-	if collection.SymbolsToIndex != nil {
-		for _, symbol := range *collection.SymbolsToIndex {
-			data.SymbolsToIndex = append(data.SymbolsToIndex, types.StringValue(symbol))
+		if collection == nil {
+			return
 		}
 	}
 
-	data.TokenSeparators = []types.String{}
-	if collection.TokenSeparators != nil {
-		for _, token := range *collection.TokenSeparators {
-			data.TokenSeparators = append(data.TokenSeparators, types.StringValue(token))
+	if isReindexViaAlias(data) {
+		_, err = r.client.Aliases().Upsert(ctx, data.Name.ValueString(), &api.CollectionAliasSchema{CollectionName: collection.Name})
+
+		if err != nil {
+			if _, deleteErr := r.client.Collection(collection.Name).Delete(ctx); deleteErr != nil {
+				tflog.Warn(ctx, "###Unable to delete collection "+collection.Name+": "+deleteErr.Error())
+			}
+
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create collection alias, got error: %s", err))
+			return
 		}
+	} else {
+		data.Name = types.StringValue(collection.Name)
+	}
+
+	data.Id = types.StringValue(data.Name.ValueString())
+	data.PhysicalName = types.StringValue(collection.Name)
+
+	resp.Diagnostics.Append(flattenCollection(collection, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -418,9 +657,42 @@ func (r *CollectionResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultCollectionReadTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	id := data.Id.ValueString()
 
-	collection, err := r.client.Collection(id).Retrieve(ctx)
+	if isReindexViaAlias(data) {
+		alias, err := r.client.Alias(id).Retrieve(ctx)
+
+		switch {
+		case err == nil:
+			id = alias.CollectionName
+		case !strings.Contains(err.Error(), "Not Found"):
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to retrieve collection alias, got error: %s", err))
+			return
+		case data.PhysicalName.IsNull() || data.PhysicalName.ValueString() == "":
+			resp.State.RemoveResource(ctx)
+			return
+		default:
+			// The collection may outlive its alias, removing it from the state
+			// would orphan it and make the next create fail
+			id = data.PhysicalName.ValueString()
+			resp.Diagnostics.AddWarning(
+				"Collection alias missing",
+				fmt.Sprintf("Alias %q of collection %q was deleted outside Terraform, searches through it fail. Create it again pointing to %q, or delete the collection.", data.Id.ValueString(), id, id),
+			)
+		}
+	}
+
+	collection, err := r.client.retrieveCollection(ctx, id)
 
 	if err != nil {
 		if strings.Contains(err.Error(), "Not Found") {
@@ -434,35 +706,143 @@ func (r *CollectionResource) Read(ctx context.Context, req resource.ReadRequest,
 
 	tflog.Info(ctx, "###Got collection name:"+collection.Name)
 
-	data.Id = types.StringValue(collection.Name)
-	data.Name = types.StringValue(collection.Name)
+	if !isReindexViaAlias(data) {
+		data.Id = types.StringValue(collection.Name)
+		data.Name = types.StringValue(collection.Name)
+	}
+
+	// Imported collections and states from older versions have no strategy yet
+	if data.FieldChangeStrategy.IsNull() {
+		data.FieldChangeStrategy = types.StringValue(fieldChangeStrategyInPlace)
+	}
+
+	if data.ManagedFields.IsNull() {
+		data.ManagedFields = types.StringValue(managedFieldsAll)
+	}
+
+	if data.CopySynonyms.IsNull() {
+		data.CopySynonyms = types.BoolValue(true)
+	}
+
+	if data.CopyOverrides.IsNull() {
+		data.CopyOverrides = types.BoolValue(true)
+	}
+
+	data.PhysicalName = types.StringValue(collection.Name)
+	priorFields := data.Fields
+
+	resp.Diagnostics.Append(flattenCollection(collection, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Imported collections have no prior fields to compare with. Fields
+	// created for a pattern field are expected, with managed_fields all they
+	// show up in the plan instead.
+	if drift := collectionFieldsDrift(priorFields, filterPatternMatchedFields(data.Fields, priorFields)); len(priorFields) > 0 && len(drift) > 0 {
+		resp.Diagnostics.AddWarning(
+			"Collection changed outside Terraform",
+			fmt.Sprintf("The schema of collection %q was changed outside Terraform: %s. Applying the configuration reverts these changes.", data.Name.ValueString(), strings.Join(drift, ", ")),
+		)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// collectionModelToSchema builds the create request of a collection from the
+// resource model, using name as the collection name.
+func collectionModelToSchema(data CollectionResourceModel, name string) (*collectionSchemaAPI, error) {
+	schema := &collectionSchemaAPI{}
+	schema.Name = name
+	schema.DefaultSortingField = data.DefaultSortingField.ValueStringPointer()
+	schema.EnableNestedFields = data.EnableNestedFields.ValueBoolPointer()
+
+	symbolsToIndex := []string{}
+	for _, symbol := range data.SymbolsToIndex {
+		symbolsToIndex = append(symbolsToIndex, symbol.ValueString())
+	}
+	schema.SymbolsToIndex = &symbolsToIndex
+
+	tokensSeparators := []string{}
+	for _, token := range data.TokenSeparators {
+		tokensSeparators = append(tokensSeparators, token.ValueString())
+	}
+	schema.TokenSeparators = &tokensSeparators
+
+	fields := []collectionFieldAPI{}
+
+	for _, field := range data.Fields {
+		fields = append(fields, filedModelToApiField(field))
+	}
+
+	schema.Fields = fields
+
+	if !data.Metadata.IsNull() {
+		metadata, err := parseJsonStringToMap(data.Metadata.ValueString())
+		if err != nil {
+			return nil, err
+		}
+		schema.Metadata = &metadata
+	}
+
+	return schema, nil
+}
+
+// flattenCollection sets the collection settings and fields returned by the
+// API on the model. Fields keep the order they have in the model. With
+// managed_fields declared_only, fields created for a pattern field of the
+// model are left out. The fields a cloned collection inherited from its
+// source collection are always left out.
+func flattenCollection(collection *collectionResponseAPI, data *CollectionResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
 
 	if collection.DefaultSortingField != nil && *collection.DefaultSortingField != "" {
 		data.DefaultSortingField = types.StringPointerValue(collection.DefaultSortingField)
 	}
 
 	data.EnableNestedFields = types.BoolPointerValue(collection.EnableNestedFields)
-	data.Fields = flattenCollectionFields(collection.Fields)
+	data.NumDocuments = types.Int64PointerValue(collection.NumDocuments)
+	data.CreatedAt = types.Int64PointerValue(collection.CreatedAt)
+	fields := flattenCollectionFields(collection.Fields)
+	if data.ManagedFields.ValueString() == managedFieldsDeclaredOnly {
+		fields = filterPatternMatchedFields(fields, data.Fields)
+	}
+	if !data.SourceCollection.IsNull() {
+		fields = filterDeclaredFields(fields, data.Fields)
+	}
+	data.Fields = keepEmbedSecrets(orderFieldsLike(fields, data.Fields), data.Fields)
+
+	metadata, err := flattenCollectionMetadata(collection.Metadata)
+	if err != nil {
+		diags.AddError("JSON format error", fmt.Sprintf("Unable to parse collection metadata, got error: %s", err))
+		return diags
+	}
+
+	// Empty metadata is the same as none: Typesense may omit the configured {},
+	// or return {} once the metadata was removed from the configuration
+	if metadata.IsNull() && isEmptyJsonObject(data.Metadata) {
+		metadata = jsontypes.NewNormalizedValue("{}")
+	} else if data.Metadata.IsNull() && isEmptyJsonObject(metadata) {
+		metadata = jsontypes.NewNormalizedNull()
+	}
+	data.Metadata = metadata
 
+	data.SymbolsToIndex = []types.String{}
 	if collection.SymbolsToIndex != nil {
-		data.SymbolsToIndex = []types.String{}
-		if collection.SymbolsToIndex != nil {
-			for _, symbol := range *collection.SymbolsToIndex {
-				data.SymbolsToIndex = append(data.SymbolsToIndex, types.StringValue(symbol))
-			}
+		for _, symbol := range *collection.SymbolsToIndex {
+			data.SymbolsToIndex = append(data.SymbolsToIndex, types.StringValue(symbol))
 		}
 	}
 
+	data.TokenSeparators = []types.String{}
 	if collection.TokenSeparators != nil {
-		data.TokenSeparators = []types.String{}
-		if collection.TokenSeparators != nil {
-			for _, token := range *collection.TokenSeparators {
-				data.TokenSeparators = append(data.TokenSeparators, types.StringValue(token))
-			}
+		for _, token := range *collection.TokenSeparators {
+			data.TokenSeparators = append(data.TokenSeparators, types.StringValue(token))
 		}
 	}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	return diags
 }
 
 func boolPointerValueWithDefault(ptr *bool, defaultVal bool) types.Bool {
@@ -486,7 +866,52 @@ func intPointerValue(ptr *int) types.Int64 {
 	return types.Int64Value(int64(*ptr))
 }
 
-func flattenCollectionFields(fields []api.Field) []CollectionResourceFieldModel {
+// stringListPointerValue converts an optional string array to a list,
+// defaulting to an empty list.
+func stringListPointerValue(ptr *[]string) types.List {
+	values := []attr.Value{}
+	if ptr != nil {
+		for _, item := range *ptr {
+			values = append(values, types.StringValue(item))
+		}
+	}
+	return types.ListValueMust(types.StringType, values)
+}
+
+// stringListValues returns the known string elements of a list.
+func stringListValues(list types.List) []string {
+	values := []string{}
+	for _, element := range list.Elements() {
+		if item, ok := element.(types.String); ok && !item.IsNull() && !item.IsUnknown() {
+			values = append(values, item.ValueString())
+		}
+	}
+	return values
+}
+
+// isEmptyJsonObject reports whether value is a known, empty JSON object.
+func isEmptyJsonObject(value jsontypes.Normalized) bool {
+	if value.IsNull() || value.IsUnknown() {
+		return false
+	}
+
+	values, err := parseJsonStringToMap(value.ValueString())
+	return err == nil && len(values) == 0
+}
+
+// flattenCollectionMetadata converts the collection metadata to JSON. Missing
+// metadata is null, while an empty object is kept as such.
+func flattenCollectionMetadata(metadata map[string]interface{}) (jsontypes.Normalized, error) {
+	if metadata == nil {
+		return jsontypes.NewNormalizedNull(), nil
+	}
+	if len(metadata) == 0 {
+		return jsontypes.NewNormalizedValue("{}"), nil
+	}
+	return parseMapToJsonString(metadata)
+}
+
+func flattenCollectionFields(fields []collectionFieldAPI) []CollectionResourceFieldModel {
 	if fields != nil {
 		fis := make([]CollectionResourceFieldModel, len(fields))
 
@@ -503,7 +928,20 @@ func flattenCollectionFields(fields []api.Field) []CollectionResourceFieldModel
 			field.StemDictionary = stringPointerValueWithDefault(fieldResponse.StemDictionary, "")
 			field.Locale = stringPointerValueWithDefault(fieldResponse.Locale, "")
 			field.Store = boolPointerValueWithDefault(fieldResponse.Store, true)
+			field.RangeIndex = boolPointerValueWithDefault(fieldResponse.RangeIndex, false)
+			field.TokenSeparators = stringListPointerValue(fieldResponse.TokenSeparators)
+			field.SymbolsToIndex = stringListPointerValue(fieldResponse.SymbolsToIndex)
 			field.NumDim = intPointerValue(fieldResponse.NumDim)
+			field.VecDist = types.StringNull()
+			field.HnswParams = types.ObjectNull(fieldHnswParamsAttrTypes)
+			if fieldResponse.Type == "float[]" && (fieldResponse.NumDim != nil || fieldResponse.Embed != nil) {
+				field.VecDist = stringPointerValueWithDefault(fieldResponse.VecDist, defaultVecDist)
+				field.HnswParams = flattenFieldHnswParams(fieldResponse.HnswParams)
+			}
+			if fieldResponse.Reference != nil && *fieldResponse.Reference != "" {
+				field.Reference = types.StringPointerValue(fieldResponse.Reference)
+			}
+			field.AsyncReference = boolPointerValueWithDefault(fieldResponse.AsyncReference, false)
 			if fieldResponse.Embed != nil {
 				field.Embed = flattenFieldEmbed(fieldResponse.Embed)
 			}
@@ -528,21 +966,64 @@ func (r *CollectionResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
-	stateItems := make(map[string]CollectionResourceFieldModel)
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultCollectionUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
 
-	for i := 0; i < len(state.Fields); i += 1 {
-		stateItems[state.Fields[i].Name.ValueString()] = state.Fields[i]
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	schema := &api.CollectionUpdateSchema{}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 
-	var drop = new(bool)
-	*drop = true
+	// Truncate first so that a reindex in the same apply has nothing to copy
+	if truncateTriggered(state, plan) {
+		name := collectionPhysicalName(state)
+		tflog.Info(ctx, "###Truncating collection "+name)
 
-	for _, field := range plan.Fields {
-		// item not exists, need to create
-		if _, ok := stateItems[field.Name.ValueString()]; !ok {
-			schema.Fields = append(schema.Fields, filedModelToApiField(field))
+		if err := r.client.truncateCollectionDocuments(ctx, name); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to truncate collection, got error: %s", err))
+			return
+		}
+	}
+
+	if isReindexViaAlias(state) && isReindexViaAlias(plan) && len(reindexReasons(state, plan)) > 0 {
+		collection := r.reindexCollection(ctx, plan, state, &resp.Diagnostics)
+
+		if collection == nil {
+			return
+		}
+
+		plan.Id = types.StringValue(plan.Name.ValueString())
+		plan.PhysicalName = types.StringValue(collection.Name)
+
+		resp.Diagnostics.Append(flattenCollection(collection, &plan)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+		return
+	}
+
+	physicalName := collectionPhysicalName(state)
+
+	stateItems := make(map[string]CollectionResourceFieldModel)
+
+	for i := 0; i < len(state.Fields); i += 1 {
+		stateItems[state.Fields[i].Name.ValueString()] = state.Fields[i]
+	}
+
+	schema := &collectionUpdateSchemaAPI{}
+
+	var drop = new(bool)
+	*drop = true
+
+	for _, field := range plan.Fields {
+		// item not exists, need to create
+		if _, ok := stateItems[field.Name.ValueString()]; !ok {
+			schema.Fields = append(schema.Fields, filedModelToApiField(field))
 
 			tflog.Info(ctx, "###Field will be created: "+field.Name.ValueString())
 
@@ -550,13 +1031,19 @@ func (r *CollectionResource) Update(ctx context.Context, req resource.UpdateRequ
 			// item was changed, need to update
 
 			schema.Fields = append(schema.Fields,
-				api.Field{
+				collectionFieldAPI{Field: api.Field{
 					Drop: drop,
 					Name: field.Name.ValueString(),
-				},
+				}},
 				filedModelToApiField(field))
 			tflog.Info(ctx, "###Field will be updated: "+field.Name.ValueString())
 
+		} else if embedSecretsChanged(stateItems[field.Name.ValueString()], field) {
+			// new credentials of the embedding model, the field is sent again
+			// without being dropped so documents are not embedded again
+			schema.Fields = append(schema.Fields, filedModelToApiField(field))
+			tflog.Info(ctx, "###Field embedding model secrets will be updated: "+field.Name.ValueString())
+
 		} else {
 			// item was not changed, do nothing
 			tflog.Info(ctx, "###Field remaining the same: "+field.Name.ValueString())
@@ -566,97 +1053,802 @@ func (r *CollectionResource) Update(ctx context.Context, req resource.UpdateRequ
 		delete(stateItems, field.Name.ValueString())
 	}
 
+	// Fields Typesense auto-created for a pattern field are never dropped
+	// with declared_only
+	var keepPatterns []*regexp.Regexp
+	if plan.ManagedFields.ValueString() == managedFieldsDeclaredOnly {
+		keepPatterns = declaredFieldPatterns(plan.Fields)
+	}
+
 	for _, field := range stateItems {
+		if matchesAnyPattern(field.Name.ValueString(), keepPatterns) {
+			tflog.Info(ctx, "###Field was created for a pattern field, keeping it: "+field.Name.ValueString())
+			continue
+		}
+
 		schema.Fields = append(schema.Fields,
-			api.Field{
+			collectionFieldAPI{Field: api.Field{
 				Drop: drop,
 				Name: field.Name.ValueString(),
-			})
+			}})
 		tflog.Info(ctx, "###Field will be deleted: "+field.Name.ValueString())
 	}
 
-	// Only call Typesense API if there are actual field changes
+	if !plan.Metadata.Equal(state.Metadata) {
+		metadata := map[string]interface{}{}
+		if !plan.Metadata.IsNull() {
+			var err error
+			metadata, err = parseJsonStringToMap(plan.Metadata.ValueString())
+			if err != nil {
+				resp.Diagnostics.AddError("JSON format error", fmt.Sprintf("Unable to parse collection metadata, got error: %s", err))
+				return
+			}
+		}
+		schema.Metadata = &metadata
+		tflog.Info(ctx, "###Metadata will be updated")
+	}
+
+	// Only call Typesense API if there are actual changes
+	if len(schema.Fields) > 0 || schema.Metadata != nil {
+		if !r.alterCollection(ctx, physicalName, schema, &resp.Diagnostics) {
+			return
+		}
+	}
+
+	// Read back the updated collection to get all computed field attributes
+	collection, err := r.client.retrieveCollection(ctx, physicalName)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to retrieve updated collection, got error: %s", err))
+		return
+	}
+
+	if !isReindexViaAlias(plan) {
+		plan.Id = types.StringValue(collection.Name)
+		plan.Name = types.StringValue(collection.Name)
+	}
+
+	plan.PhysicalName = types.StringValue(collection.Name)
+	plannedNumDocuments := plan.NumDocuments
+
+	resp.Diagnostics.Append(flattenCollection(collection, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Documents indexed since the refresh must not make the result differ
+	// from the plan, they are picked up by the next refresh instead
+	if !plannedNumDocuments.IsUnknown() {
+		plan.NumDocuments = plannedNumDocuments
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// alterCollection applies schema changes to a collection. Typesense rejects
+// concurrent alterations, so it fails if another one is in progress. The
+// alter request blocks until the documents are altered, their progress is
+// logged meanwhile, and the alteration is waited for in case it continues in
+// the background after the request. It reports whether the collection was
+// altered.
+func (r *CollectionResource) alterCollection(ctx context.Context, name string, schema *collectionUpdateSchemaAPI, diags *diag.Diagnostics) bool {
+	change, err := r.client.collectionSchemaChange(ctx, name)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to retrieve schema changes in progress, got error: %s", err))
+		return false
+	}
+
+	if change != nil {
+		diags.AddError(
+			"Schema change in progress",
+			fmt.Sprintf("Collection %q is already being altered (%d documents validated, %d altered), Typesense rejects concurrent alterations. Wait for it to complete and apply again.", name, change.ValidatedDocs, change.AlteredDocs),
+		)
+		return false
+	}
+
+	progressCtx, stopProgress := context.WithCancel(ctx)
+	progressDone := make(chan struct{})
+
+	go func() {
+		defer close(progressDone)
+		r.client.reportSchemaChangeProgress(progressCtx, name)
+	}()
+
+	err = r.client.updateCollection(ctx, name, schema)
+
+	stopProgress()
+	<-progressDone
+
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to update collection %q, got error: %s", name, err))
+		return false
+	}
+
 	if len(schema.Fields) > 0 {
-		_, err := r.client.Collection(state.Id.ValueString()).Update(ctx, schema)
+		if err := r.client.waitForSchemaChange(ctx, name); err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to wait for the schema change to complete, got error: %s", err))
+			return false
+		}
+	}
 
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update collection, got error: %s", err))
+	return true
+}
+
+func (r *CollectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data CollectionResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultCollectionDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	// Check deletion protection
+	if data.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddError(
+			"Cannot destroy collection",
+			fmt.Sprintf("Collection %q has deletion_protection set to true. Set it to false before destroying.", data.Name.ValueString()),
+		)
+		return
+	}
+
+	if data.BackupOnDestroy != nil {
+		backupPath := data.BackupOnDestroy.Path.ValueString()
+
+		tflog.Info(ctx, "###Backup collection "+collectionPhysicalName(data)+" to "+backupPath)
+
+		err := r.client.backupCollectionDocuments(ctx, collectionPhysicalName(data), backupPath, data.BackupOnDestroy.Gzip.ValueBool())
+
+		if err != nil && !strings.Contains(err.Error(), "Not Found") {
+			resp.Diagnostics.AddError("Backup Error", fmt.Sprintf("Unable to export collection documents to %s, got error: %s", backupPath, err))
 			return
 		}
 	}
 
-	// Read back the updated collection to get all computed field attributes
-	collection, err := r.client.Collection(state.Id.ValueString()).Retrieve(ctx)
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to retrieve updated collection, got error: %s", err))
-		return
-	}
+	if isReindexViaAlias(data) {
+		tflog.Warn(ctx, "###Delete collection alias with id="+data.Id.ValueString())
+
+		_, err := r.client.Alias(data.Id.ValueString()).Delete(ctx)
+
+		if err != nil && !strings.Contains(err.Error(), "Not Found") {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete collection alias, got error: %s", err))
+			return
+		}
+	}
+
+	tflog.Warn(ctx, "###Delete collection with id="+collectionPhysicalName(data))
+
+	_, err := r.client.Collection(collectionPhysicalName(data)).Delete(ctx)
+
+	if err != nil {
+		if strings.Contains(err.Error(), "Not Found") {
+			resp.State.RemoveResource(ctx)
+		} else {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete collection, got error: %s", err))
+		}
+
+		return
+	}
+
+	data.Id = types.StringValue("")
+}
+
+func (r *CollectionResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	schemaV0 := collectionResourceSchemaV0()
+
+	return map[int64]resource.StateUpgrader{
+		// Version 0 stored fields as a set, they are kept in the stored order.
+		// The configured order is not known here, so the first plan after the
+		// upgrade may reorder fields, Update matches them by name and sends
+		// nothing for a reordering.
+		0: {
+			PriorSchema: &schemaV0,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior collectionResourceModelV0
+
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				// Attributes added since version 0 are filled in by Read
+				data := CollectionResourceModel{
+					Id:                  prior.Id,
+					Name:                prior.Name,
+					DefaultSortingField: prior.DefaultSortingField,
+					Fields:              upgradeCollectionFieldsV0(prior.Fields),
+					EnableNestedFields:  prior.EnableNestedFields,
+					SymbolsToIndex:      prior.SymbolsToIndex,
+					TokenSeparators:     prior.TokenSeparators,
+					DeletionProtection:  prior.DeletionProtection,
+					Metadata:            jsontypes.NewNormalizedNull(),
+					FieldChangeStrategy: types.StringValue(fieldChangeStrategyInPlace),
+					PhysicalName:        prior.Name,
+					ManagedFields:       types.StringValue(managedFieldsAll),
+					NumDocuments:        types.Int64Null(),
+					CreatedAt:           types.Int64Null(),
+					TruncateOn:          types.MapNull(types.StringType),
+					SourceCollection:    types.StringNull(),
+					CopySynonyms:        types.BoolValue(true),
+					CopyOverrides:       types.BoolValue(true),
+					Timeouts:            timeouts.Value{Object: types.ObjectNull(timeoutsAttrTypes)},
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			},
+		},
+	}
+}
+
+// collectionResourceModelV0 is the state of version 0. It and its nested
+// models are frozen copies of the models of the releases that wrote it.
+type collectionResourceModelV0 struct {
+	Id                  types.String                     `tfsdk:"id"`
+	Name                types.String                     `tfsdk:"name"`
+	DefaultSortingField types.String                     `tfsdk:"default_sorting_field"`
+	Fields              []collectionResourceFieldModelV0 `tfsdk:"fields"`
+	EnableNestedFields  types.Bool                       `tfsdk:"enable_nested_fields"`
+	SymbolsToIndex      []types.String                   `tfsdk:"symbols_to_index"`
+	TokenSeparators     []types.String                   `tfsdk:"token_separators"`
+	DeletionProtection  types.Bool                       `tfsdk:"deletion_protection"`
+}
+
+type collectionResourceFieldModelV0 struct {
+	Name           types.String                 `tfsdk:"name"`
+	Facet          types.Bool                   `tfsdk:"facet"`
+	Index          types.Bool                   `tfsdk:"index"`
+	Optional       types.Bool                   `tfsdk:"optional"`
+	Sort           types.Bool                   `tfsdk:"sort"`
+	Infix          types.Bool                   `tfsdk:"infix"`
+	Type           types.String                 `tfsdk:"type"`
+	Stem           types.Bool                   `tfsdk:"stem"`
+	StemDictionary types.String                 `tfsdk:"stem_dictionary"`
+	Locale         types.String                 `tfsdk:"locale"`
+	Store          types.Bool                   `tfsdk:"store"`
+	NumDim         types.Int64                  `tfsdk:"num_dim"`
+	Embed          *collectionFieldEmbedModelV0 `tfsdk:"embed"`
+}
+
+type collectionFieldEmbedModelV0 struct {
+	From        []types.String                          `tfsdk:"from"`
+	ModelConfig *collectionFieldEmbedModelConfigModelV0 `tfsdk:"model_config"`
+}
+
+type collectionFieldEmbedModelConfigModelV0 struct {
+	ModelName      types.String `tfsdk:"model_name"`
+	Url            types.String `tfsdk:"url"`
+	AccessToken    types.String `tfsdk:"access_token"`
+	ApiKey         types.String `tfsdk:"api_key"`
+	ClientId       types.String `tfsdk:"client_id"`
+	ClientSecret   types.String `tfsdk:"client_secret"`
+	IndexingPrefix types.String `tfsdk:"indexing_prefix"`
+	ProjectId      types.String `tfsdk:"project_id"`
+	QueryPrefix    types.String `tfsdk:"query_prefix"`
+	RefreshToken   types.String `tfsdk:"refresh_token"`
+}
+
+// upgradeCollectionFieldsV0 converts version 0 fields to the current model,
+// attributes added since version 0 are null until Read fills them in.
+func upgradeCollectionFieldsV0(prior []collectionResourceFieldModelV0) []CollectionResourceFieldModel {
+	fields := make([]CollectionResourceFieldModel, 0, len(prior))
+
+	for _, field := range prior {
+		var embed *CollectionFieldEmbedModel
+		if field.Embed != nil {
+			embed = &CollectionFieldEmbedModel{From: field.Embed.From}
+
+			if config := field.Embed.ModelConfig; config != nil {
+				embed.ModelConfig = &CollectionFieldEmbedModelConfigModel{
+					ModelName:      config.ModelName,
+					Url:            config.Url,
+					AccessToken:    config.AccessToken,
+					ApiKey:         config.ApiKey,
+					ClientId:       config.ClientId,
+					ClientSecret:   config.ClientSecret,
+					IndexingPrefix: config.IndexingPrefix,
+					ProjectId:      config.ProjectId,
+					QueryPrefix:    config.QueryPrefix,
+					RefreshToken:   config.RefreshToken,
+				}
+			}
+		}
+
+		fields = append(fields, CollectionResourceFieldModel{
+			Name:            field.Name,
+			Facet:           field.Facet,
+			Index:           field.Index,
+			Optional:        field.Optional,
+			Sort:            field.Sort,
+			Infix:           field.Infix,
+			Type:            field.Type,
+			Stem:            field.Stem,
+			StemDictionary:  field.StemDictionary,
+			Locale:          field.Locale,
+			Store:           field.Store,
+			RangeIndex:      types.BoolNull(),
+			TokenSeparators: types.ListNull(types.StringType),
+			SymbolsToIndex:  types.ListNull(types.StringType),
+			NumDim:          field.NumDim,
+			VecDist:         types.StringNull(),
+			HnswParams:      types.ObjectNull(fieldHnswParamsAttrTypes),
+			Reference:       types.StringNull(),
+			AsyncReference:  types.BoolNull(),
+			Embed:           embed,
+		})
+	}
+
+	return fields
+}
+
+// collectionResourceSchemaV0 is the schema of version 0, where fields were a
+// set. It is a frozen copy of the schema of the releases that wrote it.
+func collectionResourceSchemaV0() schema.Schema {
+	return schema.Schema{
+		Version:             0,
+		MarkdownDescription: "Group of related documents which are roughly equivalent to a table in a relational database. Terraform will still remove auto-created fields for collections with auto-type, so you need to manually update the collection schema to match generated fields",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Id identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Collection name",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"default_sorting_field": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Default sorting field",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"enable_nested_fields": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Enable nested fields, must be enabled to use object/object[] types",
+				Default:             booldefault.StaticBool(false),
+			},
+			"symbols_to_index": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "List of symbols to index",
+				Default:             listdefault.StaticValue(types.ListValueMust(types.StringType, []attr.Value{})),
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"token_separators": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "List of token separators",
+				Default:             listdefault.StaticValue(types.ListValueMust(types.StringType, []attr.Value{})),
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"deletion_protection": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Whether or not to allow Terraform to destroy the collection. Unless this field is set to false in Terraform state, a terraform destroy or terraform apply that would delete the collection will fail.",
+				Default:             booldefault.StaticBool(false),
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"fields": schema.SetNestedBlock{
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Required: true,
+						},
+						"facet": schema.BoolAttribute{
+							Optional:    true,
+							Computed:    true,
+							Description: "Facet field. Defaults to false.",
+						},
+						"index": schema.BoolAttribute{
+							Optional:    true,
+							Computed:    true,
+							Description: "Index field. Defaults to true.",
+						},
+						"optional": schema.BoolAttribute{
+							Optional:    true,
+							Computed:    true,
+							Description: "Optional field. Defaults to false.",
+						},
+						"sort": schema.BoolAttribute{
+							Optional:    true,
+							Computed:    true,
+							Description: "Sort field. Defaults to false.",
+						},
+						"infix": schema.BoolAttribute{
+							Optional:    true,
+							Computed:    true,
+							Description: "Infix field. Defaults to false.",
+						},
+						"type": schema.StringAttribute{
+							Required:    true,
+							Description: "Field type.",
+							Validators: []validator.String{
+								stringvalidator.OneOf(
+									"string",
+									"int32",
+									"int64",
+									"float",
+									"bool",
+									"geopoint",
+									"object",
+									"string[]",
+									"int32[]",
+									"int64[]",
+									"float[]",
+									"bool[]",
+									"geopoint[]",
+									"object[]",
+									"string*",
+									"image",
+									"auto",
+								),
+							},
+						},
+						"stem": schema.BoolAttribute{
+							Optional:    true,
+							Computed:    true,
+							Description: "Enable stemming on field. Defaults to false.",
+						},
+						"stem_dictionary": schema.StringAttribute{
+							Optional:    true,
+							Computed:    true,
+							Description: "Custom stemming dictionary. Defaults to empty string.",
+						},
+						"locale": schema.StringAttribute{
+							Optional:    true,
+							Computed:    true,
+							Description: "Locale for language-specific tokenization. Defaults to empty string.",
+						},
+						"store": schema.BoolAttribute{
+							Optional:    true,
+							Computed:    true,
+							Description: "Store field value on disk. Defaults to true.",
+						},
+						"num_dim": schema.Int64Attribute{
+							Optional:    true,
+							Description: "Number of dimensions for vector fields (float[] type). Required for vector search.",
+						},
+					},
+					Blocks: map[string]schema.Block{
+						"embed": schema.SingleNestedBlock{
+							Attributes: map[string]schema.Attribute{
+								"from": schema.ListAttribute{
+									ElementType: types.StringType,
+									Optional:    true,
+									Description: "Fields to generate the embedding from",
+								},
+							},
+							Blocks: map[string]schema.Block{
+								"model_config": schema.SingleNestedBlock{
+									Attributes: map[string]schema.Attribute{
+										"model_name": schema.StringAttribute{
+											Optional:    true,
+											Description: "Model name for embedding generation (e.g. ts/clip-vit-b-p32)",
+										},
+										"url": schema.StringAttribute{
+											Optional:    true,
+											Computed:    true,
+											Description: "URL for remote embedding model",
+										},
+										"access_token": schema.StringAttribute{
+											Optional:    true,
+											Computed:    true,
+											Sensitive:   true,
+											Description: "Access token for authentication",
+										},
+										"api_key": schema.StringAttribute{
+											Optional:    true,
+											Computed:    true,
+											Sensitive:   true,
+											Description: "API key for authentication",
+										},
+										"client_id": schema.StringAttribute{
+											Optional:    true,
+											Computed:    true,
+											Description: "Client ID for OAuth",
+										},
+										"client_secret": schema.StringAttribute{
+											Optional:    true,
+											Computed:    true,
+											Sensitive:   true,
+											Description: "Client secret for OAuth",
+										},
+										"indexing_prefix": schema.StringAttribute{
+											Optional:    true,
+											Computed:    true,
+											Description: "Prefix added to text during indexing",
+										},
+										"project_id": schema.StringAttribute{
+											Optional:    true,
+											Computed:    true,
+											Description: "Project ID for cloud providers",
+										},
+										"query_prefix": schema.StringAttribute{
+											Optional:    true,
+											Computed:    true,
+											Description: "Prefix added to text during querying",
+										},
+										"refresh_token": schema.StringAttribute{
+											Optional:    true,
+											Computed:    true,
+											Sensitive:   true,
+											Description: "Refresh token for OAuth",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *CollectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// collectionConfigField holds the field attributes checked by ValidateConfig.
+type collectionConfigField struct {
+	Name        types.String
+	Type        types.String
+	NumDim      types.Int64
+	Sort        types.Bool
+	EmbedFrom   []types.String
+	ModelConfig *CollectionFieldEmbedModelConfigModel
+}
+
+// getCollectionConfigFields reads the field attributes checked by
+// ValidateConfig one by one into framework types, as the configuration may
+// hold unknown values CollectionResourceModel cannot decode. Fields with an
+// unknown embed block or embed.from list are returned without them.
+func getCollectionConfigFields(ctx context.Context, config tfsdk.Config, fields types.List, diags *diag.Diagnostics) []collectionConfigField {
+	configFields := make([]collectionConfigField, len(fields.Elements()))
+
+	for i := range configFields {
+		fieldPath := path.Root("fields").AtListIndex(i)
+		field := &configFields[i]
+
+		diags.Append(config.GetAttribute(ctx, fieldPath.AtName("name"), &field.Name)...)
+		diags.Append(config.GetAttribute(ctx, fieldPath.AtName("type"), &field.Type)...)
+		diags.Append(config.GetAttribute(ctx, fieldPath.AtName("num_dim"), &field.NumDim)...)
+		diags.Append(config.GetAttribute(ctx, fieldPath.AtName("sort"), &field.Sort)...)
+
+		var embed types.Object
+		diags.Append(config.GetAttribute(ctx, fieldPath.AtName("embed"), &embed)...)
+		if embed.IsNull() || embed.IsUnknown() {
+			continue
+		}
+
+		var from types.List
+		diags.Append(config.GetAttribute(ctx, fieldPath.AtName("embed").AtName("from"), &from)...)
+		if !from.IsUnknown() {
+			diags.Append(from.ElementsAs(ctx, &field.EmbedFrom, false)...)
+		}
+
+		var modelConfig types.Object
+		diags.Append(config.GetAttribute(ctx, fieldPath.AtName("embed").AtName("model_config"), &modelConfig)...)
+		if !modelConfig.IsNull() && !modelConfig.IsUnknown() {
+			field.ModelConfig = &CollectionFieldEmbedModelConfigModel{}
+			diags.Append(modelConfig.As(ctx, field.ModelConfig, basetypes.ObjectAsOptions{})...)
+		}
+	}
+
+	return configFields
+}
+
+func (r *CollectionResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var defaultSortingField, sourceCollection, fieldChangeStrategy types.String
+	var enableNestedFields types.Bool
+	var fields types.List
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("default_sorting_field"), &defaultSortingField)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("source_collection"), &sourceCollection)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("field_change_strategy"), &fieldChangeStrategy)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("enable_nested_fields"), &enableNestedFields)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("fields"), &fields)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	configFields := getCollectionConfigFields(ctx, req.Config, fields, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	fieldTypes := make(map[string]types.String, len(configFields))
+	fieldsKnown := !fields.IsUnknown()
+
+	for _, field := range configFields {
+		if field.Name.IsUnknown() {
+			fieldsKnown = false
+			continue
+		}
+		fieldTypes[field.Name.ValueString()] = field.Type
+	}
+
+	// Cloned collections also have the fields of their source collection
+	allFieldsDeclared := fieldsKnown && sourceCollection.IsNull()
+
+	// A reindex rebuilds the next collection from the declared fields only,
+	// the fields cloned from the source collection would be lost.
+	if !sourceCollection.IsNull() && fieldChangeStrategy.ValueString() == fieldChangeStrategyReindexViaAlias {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("field_change_strategy"),
+			"Invalid field change strategy",
+			fmt.Sprintf("Collections cloned with source_collection cannot use the %q field change strategy, the fields of the source collection are not declared.", fieldChangeStrategyReindexViaAlias),
+		)
+	}
+
+	if !defaultSortingField.IsNull() && !defaultSortingField.IsUnknown() {
+		name := defaultSortingField.ValueString()
+
+		if fieldType, ok := fieldTypes[name]; !ok {
+			if allFieldsDeclared {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("default_sorting_field"),
+					"Invalid default sorting field",
+					fmt.Sprintf("Field %q is not declared in the collection.", name),
+				)
+			}
+		} else if !fieldType.IsUnknown() && !slices.Contains([]string{"int32", "int64", "float"}, fieldType.ValueString()) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("default_sorting_field"),
+				"Invalid default sorting field",
+				fmt.Sprintf("Field %q has type %q, the default sorting field must be an int32, int64 or float field.", name, fieldType.ValueString()),
+			)
+		}
+	}
+
+	for i, field := range configFields {
+		fieldPath := path.Root("fields").AtListIndex(i)
+		fieldType := field.Type.ValueString()
 
-	plan.Id = types.StringValue(collection.Name)
-	plan.Name = types.StringValue(collection.Name)
+		if field.Type.IsUnknown() {
+			continue
+		}
 
-	if collection.DefaultSortingField != nil && *collection.DefaultSortingField != "" {
-		plan.DefaultSortingField = types.StringPointerValue(collection.DefaultSortingField)
-	}
+		if (fieldType == "object" || fieldType == "object[]") && !enableNestedFields.IsUnknown() && !enableNestedFields.ValueBool() {
+			resp.Diagnostics.AddAttributeError(
+				fieldPath.AtName("type"),
+				"Invalid field type",
+				fmt.Sprintf("Field %q has type %q, which requires enable_nested_fields to be true.", field.Name.ValueString(), fieldType),
+			)
+		}
 
-	plan.EnableNestedFields = types.BoolPointerValue(collection.EnableNestedFields)
-	plan.Fields = flattenCollectionFields(collection.Fields)
+		if !field.NumDim.IsNull() && fieldType != "float[]" {
+			resp.Diagnostics.AddAttributeError(
+				fieldPath.AtName("num_dim"),
+				"Invalid vector field",
+				fmt.Sprintf("Field %q has type %q, num_dim can only be used on float[] fields.", field.Name.ValueString(), fieldType),
+			)
+		}
 
-	plan.SymbolsToIndex = []types.String{}
-	if collection.SymbolsToIndex != nil {
-		for _, symbol := range *collection.SymbolsToIndex {
-			plan.SymbolsToIndex = append(plan.SymbolsToIndex, types.StringValue(symbol))
+		if field.Sort.ValueBool() && fieldType == "string[]" {
+			resp.Diagnostics.AddAttributeError(
+				fieldPath.AtName("sort"),
+				"Invalid sort field",
+				fmt.Sprintf("Field %q has type string[], which cannot be sorted.", field.Name.ValueString()),
+			)
 		}
-	}
 
-	plan.TokenSeparators = []types.String{}
-	if collection.TokenSeparators != nil {
-		for _, token := range *collection.TokenSeparators {
-			plan.TokenSeparators = append(plan.TokenSeparators, types.StringValue(token))
+		if field.ModelConfig != nil {
+			validateEmbedModelConfig(field.ModelConfig, fieldPath.AtName("embed").AtName("model_config"), &resp.Diagnostics)
 		}
-	}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
-}
+		for j, from := range field.EmbedFrom {
+			if from.IsUnknown() {
+				continue
+			}
 
-func (r *CollectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var data CollectionResourceModel
+			fromType, ok := fieldTypes[from.ValueString()]
+			if !ok {
+				if allFieldsDeclared {
+					resp.Diagnostics.AddAttributeError(
+						fieldPath.AtName("embed").AtName("from").AtListIndex(j),
+						"Invalid embedding source",
+						fmt.Sprintf("Field %q embeds field %q, which is not declared in the collection.", field.Name.ValueString(), from.ValueString()),
+					)
+				}
+			} else if !fromType.IsUnknown() && !slices.Contains([]string{"string", "string[]", "image"}, fromType.ValueString()) {
+				resp.Diagnostics.AddAttributeError(
+					fieldPath.AtName("embed").AtName("from").AtListIndex(j),
+					"Invalid embedding source",
+					fmt.Sprintf("Field %q embeds field %q of type %q, embeddings can only be generated from string, string[] or image fields.", field.Name.ValueString(), from.ValueString(), fromType.ValueString()),
+				)
+			}
+		}
+	}
+}
 
-	// Read Terraform prior state data into the model
-	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+// embeddingModelProviders maps the model_name prefixes of the supported
+// embedding providers to the model_config attributes they require.
+var embeddingModelProviders = map[string][]string{
+	"ts/":     {},
+	"openai/": {"api_key"},
+	"google/": {"api_key"},
+	"gcp/":    {"project_id", "access_token", "refresh_token", "client_id", "client_secret"},
+	"azure/":  {"url", "api_key"},
+}
 
-	if resp.Diagnostics.HasError() {
+// validateEmbedModelConfig checks the model_name provider prefix and the
+// attributes required by the provider.
+func validateEmbedModelConfig(config *CollectionFieldEmbedModelConfigModel, configPath path.Path, diags *diag.Diagnostics) {
+	if config.ModelName.IsUnknown() {
 		return
 	}
 
-	// Check deletion protection
-	if data.DeletionProtection.ValueBool() {
-		resp.Diagnostics.AddError(
-			"Cannot destroy collection",
-			fmt.Sprintf("Collection %q has deletion_protection set to true. Set it to false before destroying.", data.Name.ValueString()),
+	if config.ModelName.IsNull() {
+		diags.AddAttributeError(
+			configPath.AtName("model_name"),
+			"Missing embedding model attribute",
+			"The embedding model config requires model_name to be set.",
 		)
 		return
 	}
 
-	tflog.Warn(ctx, "###Delete collection with id="+data.Id.ValueString())
-
-	_, err := r.client.Collection(data.Id.ValueString()).Delete(ctx)
-
-	if err != nil {
-		if strings.Contains(err.Error(), "Not Found") {
-			resp.State.RemoveResource(ctx)
-		} else {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete collection, got error: %s", err))
-		}
+	modelName := config.ModelName.ValueString()
+	provider, _, found := strings.Cut(modelName, "/")
+	required, ok := embeddingModelProviders[provider+"/"]
 
+	if !found || !ok {
+		diags.AddAttributeError(
+			configPath.AtName("model_name"),
+			"Invalid embedding model",
+			fmt.Sprintf("Model %q must be prefixed with its provider: ts/, openai/, google/, gcp/ or azure/.", modelName),
+		)
 		return
 	}
 
-	data.Id = types.StringValue("")
-}
+	values := map[string]types.String{
+		"url":           config.Url,
+		"access_token":  config.AccessToken,
+		"api_key":       config.ApiKey,
+		"client_id":     config.ClientId,
+		"client_secret": config.ClientSecret,
+		"project_id":    config.ProjectId,
+		"refresh_token": config.RefreshToken,
+	}
 
-func (r *CollectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	for _, attribute := range required {
+		if values[attribute].IsNull() {
+			diags.AddAttributeError(
+				configPath.AtName(attribute),
+				"Missing embedding model attribute",
+				fmt.Sprintf("Model %q requires %s to be set.", modelName, attribute),
+			)
+		}
+	}
 }
 
 func (r *CollectionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
@@ -681,7 +1873,7 @@ func (r *CollectionResource) ModifyPlan(ctx context.Context, req resource.Modify
 			modified = true
 		}
 		if plan.Fields[i].Optional.IsUnknown() || plan.Fields[i].Optional.IsNull() {
-			plan.Fields[i].Optional = types.BoolValue(false)
+			plan.Fields[i].Optional = types.BoolValue(isPatternFieldName(plan.Fields[i].Name.ValueString()))
 			modified = true
 		}
 		if plan.Fields[i].Sort.IsUnknown() || plan.Fields[i].Sort.IsNull() {
@@ -708,6 +1900,123 @@ func (r *CollectionResource) ModifyPlan(ctx context.Context, req resource.Modify
 			plan.Fields[i].Store = types.BoolValue(true)
 			modified = true
 		}
+		if plan.Fields[i].AsyncReference.IsUnknown() || plan.Fields[i].AsyncReference.IsNull() {
+			plan.Fields[i].AsyncReference = types.BoolValue(false)
+			modified = true
+		}
+		if plan.Fields[i].RangeIndex.IsUnknown() || plan.Fields[i].RangeIndex.IsNull() {
+			plan.Fields[i].RangeIndex = types.BoolValue(false)
+			modified = true
+		}
+		if plan.Fields[i].TokenSeparators.IsUnknown() || plan.Fields[i].TokenSeparators.IsNull() {
+			plan.Fields[i].TokenSeparators = types.ListValueMust(types.StringType, []attr.Value{})
+			modified = true
+		}
+		if plan.Fields[i].SymbolsToIndex.IsUnknown() || plan.Fields[i].SymbolsToIndex.IsNull() {
+			plan.Fields[i].SymbolsToIndex = types.ListValueMust(types.StringType, []attr.Value{})
+			modified = true
+		}
+
+		fieldPath := path.Root("fields").AtListIndex(i)
+
+		if setVectorFieldDefaults(&plan.Fields[i], fieldPath, &resp.Diagnostics) {
+			modified = true
+		}
+
+		validateFieldReference(plan.Fields[i], fieldPath, &resp.Diagnostics)
+		validatePatternField(plan.Fields[i], fieldPath, &resp.Diagnostics)
+	}
+
+	fieldNames := make(map[string]bool, len(plan.Fields))
+	for i, field := range plan.Fields {
+		if field.Name.IsUnknown() {
+			continue
+		}
+		if fieldNames[field.Name.ValueString()] {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("fields").AtListIndex(i).AtName("name"),
+				"Duplicate field name",
+				fmt.Sprintf("Field %q is declared more than once.", field.Name.ValueString()),
+			)
+		}
+		fieldNames[field.Name.ValueString()] = true
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !req.State.Raw.IsNull() {
+		var state CollectionResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if useStateForUnknownFields(plan.Fields, state.Fields) {
+			modified = true
+		}
+
+		if truncateTriggered(state, plan) {
+			plan.NumDocuments = types.Int64Unknown()
+			modified = true
+			resp.Diagnostics.AddWarning(
+				"Collection documents will be deleted",
+				fmt.Sprintf("truncate_on of collection %q changed, all its documents will be deleted.", plan.Name.ValueString()),
+			)
+		}
+
+		if isReindexViaAlias(state) && isReindexViaAlias(plan) {
+			if reasons := reindexReasons(state, plan); len(reasons) > 0 {
+				plan.Id = plan.Name
+				plan.PhysicalName = types.StringUnknown()
+				plan.NumDocuments = types.Int64Unknown()
+				plan.CreatedAt = types.Int64Unknown()
+				modified = true
+				resp.Diagnostics.AddWarning(
+					"Collection will be reindexed",
+					fmt.Sprintf("Collection %q will be copied into a new collection because of changes to %s, and the alias switched to it once all documents are copied. Documents written during the copy are lost.", plan.Name.ValueString(), strings.Join(reasons, ", ")),
+				)
+			}
+		} else if changed := changedCollectionFields(state.Fields, plan.Fields); len(changed) > 0 && !isReindexViaAlias(state) && !isReindexViaAlias(plan) {
+			fieldNames := `"` + strings.Join(changed, `", "`) + `"`
+
+			switch plan.FieldChangeStrategy.ValueString() {
+			case fieldChangeStrategyFail:
+				resp.Diagnostics.AddAttributeError(
+					path.Root("fields"),
+					"Field changes not allowed",
+					fmt.Sprintf("Fields %s of collection %q changed, but field_change_strategy is %q. Revert the changes or use another strategy.", fieldNames, plan.Name.ValueString(), fieldChangeStrategyFail),
+				)
+				return
+			case fieldChangeStrategyRecreateCollection:
+				resp.RequiresReplace = append(resp.RequiresReplace, path.Root("fields"))
+				resp.Diagnostics.AddWarning(
+					"Collection will be recreated",
+					fmt.Sprintf("Fields %s of collection %q changed, the collection will be destroyed and created again and all its documents will be lost.", fieldNames, plan.Name.ValueString()),
+				)
+			default:
+				resp.Diagnostics.AddWarning(
+					"Fields will be dropped and re-indexed",
+					fmt.Sprintf("Fields %s of collection %q changed, they will be dropped and added again, and searches on them may return incomplete results until re-indexing completes.", fieldNames, plan.Name.ValueString()),
+				)
+			}
+		}
+
+		// Delete only sees the protection once the replacement is half applied
+		if replacement := append(collectionReplacementPaths(state, plan), resp.RequiresReplace...); state.DeletionProtection.ValueBool() && len(replacement) > 0 {
+			attributes := make([]string, 0, len(replacement))
+			for _, attribute := range replacement {
+				attributes = append(attributes, attribute.String())
+			}
+
+			resp.Diagnostics.AddAttributeError(
+				path.Root("deletion_protection"),
+				"Cannot replace protected collection",
+				fmt.Sprintf("Collection %q has deletion_protection set to true, but changes to %s require replacing it, which deletes all its documents. Revert these changes, or set deletion_protection to false and apply before making them.", state.Name.ValueString(), strings.Join(attributes, ", ")),
+			)
+			return
+		}
 	}
 
 	if modified {
@@ -715,8 +2024,64 @@ func (r *CollectionResource) ModifyPlan(ctx context.Context, req resource.Modify
 	}
 }
 
-func filedModelToApiField(field CollectionResourceFieldModel) api.Field {
-	apiField := api.Field{
+// useStateForUnknownFields fills the unknown computed attributes of the
+// planned fields from the state field with the same name. Fields are matched
+// by name rather than list index, so adding, removing or moving a field does
+// not shift state values onto other fields. It reports whether any planned
+// field was modified.
+func useStateForUnknownFields(planFields []CollectionResourceFieldModel, stateFields []CollectionResourceFieldModel) bool {
+	stateItems := make(map[string]CollectionResourceFieldModel, len(stateFields))
+	for _, field := range stateFields {
+		stateItems[field.Name.ValueString()] = field
+	}
+
+	modified := false
+	useState := func(planned *types.String, state types.String) {
+		if planned.IsUnknown() {
+			*planned = state
+			modified = true
+		}
+	}
+
+	for i := range planFields {
+		field := &planFields[i]
+
+		stateField, ok := stateItems[field.Name.ValueString()]
+		if !ok || field.Name.IsUnknown() {
+			continue
+		}
+
+		useState(&field.VecDist, stateField.VecDist)
+
+		if field.HnswParams.IsUnknown() {
+			field.HnswParams = stateField.HnswParams
+			modified = true
+		}
+
+		if field.Embed == nil || field.Embed.ModelConfig == nil || stateField.Embed == nil || stateField.Embed.ModelConfig == nil {
+			continue
+		}
+
+		planned, state := field.Embed.ModelConfig, stateField.Embed.ModelConfig
+		useState(&planned.Url, state.Url)
+		useState(&planned.ClientId, state.ClientId)
+		useState(&planned.IndexingPrefix, state.IndexingPrefix)
+		useState(&planned.ProjectId, state.ProjectId)
+		useState(&planned.QueryPrefix, state.QueryPrefix)
+	}
+
+	return modified
+}
+
+// truncateTriggered reports whether truncate_on changed from a previous value
+// to another one, meaning all documents of the collection must be deleted.
+// Adding truncate_on to an existing collection never deletes its documents.
+func truncateTriggered(state CollectionResourceModel, plan CollectionResourceModel) bool {
+	return !state.TruncateOn.IsNull() && !plan.TruncateOn.IsNull() && !plan.TruncateOn.Equal(state.TruncateOn)
+}
+
+func filedModelToApiField(field CollectionResourceFieldModel) collectionFieldAPI {
+	apiField := collectionFieldAPI{Field: api.Field{
 		Name:           field.Name.ValueString(),
 		Facet:          field.Facet.ValueBoolPointer(),
 		Index:          field.Index.ValueBoolPointer(),
@@ -728,6 +2093,15 @@ func filedModelToApiField(field CollectionResourceFieldModel) api.Field {
 		StemDictionary: field.StemDictionary.ValueStringPointer(),
 		Locale:         field.Locale.ValueStringPointer(),
 		Store:          field.Store.ValueBoolPointer(),
+		RangeIndex:     field.RangeIndex.ValueBoolPointer(),
+	}}
+
+	if tokenSeparators := stringListValues(field.TokenSeparators); len(tokenSeparators) > 0 {
+		apiField.TokenSeparators = &tokenSeparators
+	}
+
+	if symbolsToIndex := stringListValues(field.SymbolsToIndex); len(symbolsToIndex) > 0 {
+		apiField.SymbolsToIndex = &symbolsToIndex
 	}
 
 	if !field.NumDim.IsNull() && !field.NumDim.IsUnknown() {
@@ -735,6 +2109,16 @@ func filedModelToApiField(field CollectionResourceFieldModel) api.Field {
 		apiField.NumDim = &numDim
 	}
 
+	if !field.Reference.IsNull() && !field.Reference.IsUnknown() {
+		apiField.Reference = field.Reference.ValueStringPointer()
+		apiField.AsyncReference = field.AsyncReference.ValueBoolPointer()
+	}
+
+	if isVectorField(field) {
+		apiField.VecDist = field.VecDist.ValueStringPointer()
+		apiField.HnswParams = fieldHnswParamsModelToAPI(field.HnswParams)
+	}
+
 	apiField.Embed = fieldEmbedModelToAPI(field.Embed)
 
 	return apiField
@@ -804,6 +2188,369 @@ func flattenFieldEmbed(embed *fieldEmbedAPI) *CollectionFieldEmbedModel {
 	return res
 }
 
+// keepEmbedSecrets copies the secrets of the embedding model configs from the
+// reference fields, since Typesense does not return them.
+func keepEmbedSecrets(fields []CollectionResourceFieldModel, reference []CollectionResourceFieldModel) []CollectionResourceFieldModel {
+	secrets := make(map[string]*CollectionFieldEmbedModelConfigModel, len(reference))
+	for _, field := range reference {
+		if field.Embed != nil && field.Embed.ModelConfig != nil {
+			secrets[field.Name.ValueString()] = field.Embed.ModelConfig
+		}
+	}
+
+	for _, field := range fields {
+		if field.Embed == nil || field.Embed.ModelConfig == nil {
+			continue
+		}
+
+		config := field.Embed.ModelConfig
+		config.AccessToken = types.StringNull()
+		config.ApiKey = types.StringNull()
+		config.ClientSecret = types.StringNull()
+		config.RefreshToken = types.StringNull()
+
+		if secret, ok := secrets[field.Name.ValueString()]; ok {
+			config.AccessToken = secret.AccessToken
+			config.ApiKey = secret.ApiKey
+			config.ClientSecret = secret.ClientSecret
+			config.RefreshToken = secret.RefreshToken
+		}
+	}
+
+	return fields
+}
+
+// isVectorField reports whether the field holds embeddings, i.e. a float[]
+// field with num_dim or embed set.
+func isVectorField(field CollectionResourceFieldModel) bool {
+	return field.Type.ValueString() == "float[]" && (!field.NumDim.IsNull() || field.Embed != nil)
+}
+
+// setVectorFieldDefaults fills in vec_dist and hnsw_params defaults on vector
+// fields and rejects them on any other field. It reports whether the field
+// was modified.
+func setVectorFieldDefaults(field *CollectionResourceFieldModel, fieldPath path.Path, diags *diag.Diagnostics) bool {
+	if !isVectorField(*field) {
+		if (!field.VecDist.IsNull() && !field.VecDist.IsUnknown()) || (!field.HnswParams.IsNull() && !field.HnswParams.IsUnknown()) {
+			diags.AddAttributeError(
+				fieldPath,
+				"Invalid vector field parameters",
+				fmt.Sprintf("Field %q sets vec_dist or hnsw_params, they can only be used on float[] fields with num_dim or embed set.", field.Name.ValueString()),
+			)
+			return false
+		}
+
+		modified := field.VecDist.IsUnknown() || field.HnswParams.IsUnknown()
+		field.VecDist = types.StringNull()
+		field.HnswParams = types.ObjectNull(fieldHnswParamsAttrTypes)
+		return modified
+	}
+
+	modified := false
+
+	if field.VecDist.IsUnknown() || field.VecDist.IsNull() {
+		field.VecDist = types.StringValue(defaultVecDist)
+		modified = true
+	}
+
+	if field.HnswParams.IsUnknown() || field.HnswParams.IsNull() {
+		field.HnswParams = hnswParamsValue(defaultHnswM, defaultHnswEfConstruction)
+		return true
+	}
+
+	m, _ := field.HnswParams.Attributes()["m"].(types.Int64)
+	efConstruction, _ := field.HnswParams.Attributes()["ef_construction"].(types.Int64)
+
+	if m.IsUnknown() || m.IsNull() || efConstruction.IsUnknown() || efConstruction.IsNull() {
+		if m.IsUnknown() || m.IsNull() {
+			m = types.Int64Value(defaultHnswM)
+		}
+		if efConstruction.IsUnknown() || efConstruction.IsNull() {
+			efConstruction = types.Int64Value(defaultHnswEfConstruction)
+		}
+		field.HnswParams = hnswParamsValue(m.ValueInt64(), efConstruction.ValueInt64())
+		modified = true
+	}
+
+	return modified
+}
+
+func hnswParamsValue(m int64, efConstruction int64) types.Object {
+	return types.ObjectValueMust(fieldHnswParamsAttrTypes, map[string]attr.Value{
+		"m":               types.Int64Value(m),
+		"ef_construction": types.Int64Value(efConstruction),
+	})
+}
+
+func flattenFieldHnswParams(hnswParams *fieldHnswParamsAPI) types.Object {
+	m := int64(defaultHnswM)
+	efConstruction := int64(defaultHnswEfConstruction)
+
+	if hnswParams != nil && hnswParams.M != nil {
+		m = int64(*hnswParams.M)
+	}
+	if hnswParams != nil && hnswParams.EfConstruction != nil {
+		efConstruction = int64(*hnswParams.EfConstruction)
+	}
+
+	return hnswParamsValue(m, efConstruction)
+}
+
+func fieldHnswParamsModelToAPI(hnswParams types.Object) *fieldHnswParamsAPI {
+	if hnswParams.IsNull() || hnswParams.IsUnknown() {
+		return nil
+	}
+
+	res := &fieldHnswParamsAPI{}
+
+	if m, ok := hnswParams.Attributes()["m"].(types.Int64); ok && !m.IsNull() && !m.IsUnknown() {
+		value := int(m.ValueInt64())
+		res.M = &value
+	}
+	if efConstruction, ok := hnswParams.Attributes()["ef_construction"].(types.Int64); ok && !efConstruction.IsNull() && !efConstruction.IsUnknown() {
+		value := int(efConstruction.ValueInt64())
+		res.EfConstruction = &value
+	}
+
+	return res
+}
+
+// validateFieldReference checks that reference has the collection.field format
+// and that async_reference is only used together with a reference.
+func validateFieldReference(field CollectionResourceFieldModel, fieldPath path.Path, diags *diag.Diagnostics) {
+	if field.Reference.IsUnknown() {
+		return
+	}
+
+	if field.Reference.IsNull() {
+		if field.AsyncReference.ValueBool() {
+			diags.AddAttributeError(
+				fieldPath.AtName("async_reference"),
+				"Invalid field reference",
+				fmt.Sprintf("Field %q sets async_reference without a reference.", field.Name.ValueString()),
+			)
+		}
+		return
+	}
+
+	collection, referencedField, found := strings.Cut(field.Reference.ValueString(), ".")
+	if !found || collection == "" || referencedField == "" {
+		diags.AddAttributeError(
+			fieldPath.AtName("reference"),
+			"Invalid field reference",
+			fmt.Sprintf("Field %q has reference %q, it must have the \"collection.field\" format.", field.Name.ValueString(), field.Reference.ValueString()),
+		)
+	}
+}
+
+// filterDeclaredFields keeps the fields with the name of a declared field.
+func filterDeclaredFields(fields []CollectionResourceFieldModel, declared []CollectionResourceFieldModel) []CollectionResourceFieldModel {
+	names := make(map[string]bool, len(declared))
+	for _, field := range declared {
+		names[field.Name.ValueString()] = true
+	}
+
+	filtered := make([]CollectionResourceFieldModel, 0, len(fields))
+	for _, field := range fields {
+		if names[field.Name.ValueString()] {
+			filtered = append(filtered, field)
+		}
+	}
+
+	return filtered
+}
+
+// orderFieldsLike sorts fields in the order of the reference fields, matched
+// by name, so the state keeps the configured order. Fields missing from the
+// reference are kept at the end in their API order.
+func orderFieldsLike(fields []CollectionResourceFieldModel, reference []CollectionResourceFieldModel) []CollectionResourceFieldModel {
+	positions := make(map[string]int, len(reference))
+	for i, field := range reference {
+		if _, ok := positions[field.Name.ValueString()]; !ok {
+			positions[field.Name.ValueString()] = i
+		}
+	}
+
+	position := func(field CollectionResourceFieldModel) int {
+		if i, ok := positions[field.Name.ValueString()]; ok {
+			return i
+		}
+		return len(reference)
+	}
+
+	ordered := make([]CollectionResourceFieldModel, len(fields))
+	copy(ordered, fields)
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return position(ordered[i]) < position(ordered[j])
+	})
+
+	return ordered
+}
+
+// collectionReplacementPaths returns the attributes whose change requires
+// replacing the collection. It mirrors their RequiresReplace plan modifiers,
+// whose result is not passed to the resource ModifyPlan.
+func collectionReplacementPaths(state CollectionResourceModel, plan CollectionResourceModel) path.Paths {
+	paths := path.Paths{}
+
+	if isReindexViaAlias(state) != isReindexViaAlias(plan) {
+		paths = append(paths, path.Root("field_change_strategy"))
+	}
+
+	if !plan.SourceCollection.Equal(state.SourceCollection) {
+		paths = append(paths, path.Root("source_collection"))
+	}
+
+	if isReindexViaAlias(state) && isReindexViaAlias(plan) {
+		return paths
+	}
+
+	if !plan.Name.Equal(state.Name) {
+		paths = append(paths, path.Root("name"))
+	}
+
+	if !plan.DefaultSortingField.Equal(state.DefaultSortingField) {
+		paths = append(paths, path.Root("default_sorting_field"))
+	}
+
+	if !slices.Equal(convertTerraformArrayToStringArray(plan.SymbolsToIndex), convertTerraformArrayToStringArray(state.SymbolsToIndex)) {
+		paths = append(paths, path.Root("symbols_to_index"))
+	}
+
+	if !slices.Equal(convertTerraformArrayToStringArray(plan.TokenSeparators), convertTerraformArrayToStringArray(state.TokenSeparators)) {
+		paths = append(paths, path.Root("token_separators"))
+	}
+
+	return paths
+}
+
+// changedCollectionFields returns the names of the fields present in both the
+// state and the plan whose attributes differ, i.e. the fields to be dropped
+// and re-indexed.
+func changedCollectionFields(stateFields []CollectionResourceFieldModel, planFields []CollectionResourceFieldModel) []string {
+	stateItems := make(map[string]CollectionResourceFieldModel, len(stateFields))
+	for _, field := range stateFields {
+		stateItems[field.Name.ValueString()] = field
+	}
+
+	changed := []string{}
+	for _, field := range planFields {
+		if stateField, ok := stateItems[field.Name.ValueString()]; ok && !fieldsEqual(stateField, field) {
+			changed = append(changed, field.Name.ValueString())
+		}
+	}
+
+	return changed
+}
+
+// collectionFieldsDrift describes the fields added, removed or modified
+// between the prior state and the collection read from Typesense. Attributes
+// null in the prior state were not tracked yet and are not reported.
+func collectionFieldsDrift(priorFields []CollectionResourceFieldModel, fields []CollectionResourceFieldModel) []string {
+	priorItems := make(map[string]CollectionResourceFieldModel, len(priorFields))
+	for _, field := range priorFields {
+		priorItems[field.Name.ValueString()] = field
+	}
+
+	drift := []string{}
+	for _, field := range fields {
+		name := field.Name.ValueString()
+
+		prior, ok := priorItems[name]
+		if !ok {
+			drift = append(drift, fmt.Sprintf("field %q was added", name))
+			continue
+		}
+		delete(priorItems, name)
+
+		if attributes := changedFieldAttributes(prior, field); len(attributes) > 0 {
+			drift = append(drift, fmt.Sprintf("field %q was modified (%s)", name, strings.Join(attributes, ", ")))
+		}
+	}
+
+	for _, field := range priorFields {
+		if _, ok := priorItems[field.Name.ValueString()]; ok {
+			drift = append(drift, fmt.Sprintf("field %q was removed", field.Name.ValueString()))
+		}
+	}
+
+	return drift
+}
+
+// changedFieldAttributes returns the names of the attributes which differ
+// between two fields, ignoring the ones null in prior.
+func changedFieldAttributes(prior, field CollectionResourceFieldModel) []string {
+	priorValue := reflect.ValueOf(prior)
+	fieldValue := reflect.ValueOf(field)
+
+	attributes := []string{}
+	for i := 0; i < priorValue.NumField(); i++ {
+		priorAttribute := priorValue.Field(i)
+
+		if value, ok := priorAttribute.Interface().(attr.Value); ok && value.IsNull() {
+			continue
+		}
+
+		if priorAttribute.Kind() == reflect.Pointer && priorAttribute.IsNil() {
+			continue
+		}
+
+		if !reflect.DeepEqual(priorAttribute.Interface(), fieldValue.Field(i).Interface()) {
+			attributes = append(attributes, priorValue.Type().Field(i).Tag.Get("tfsdk"))
+		}
+	}
+
+	return attributes
+}
+
+// fieldsEqual reports whether two fields have the same attributes, leaving
+// out the embedding model secrets: changing them does not require dropping
+// the field and embedding all documents again.
 func fieldsEqual(a, b CollectionResourceFieldModel) bool {
-	return reflect.DeepEqual(a, b)
+	return reflect.DeepEqual(withoutEmbedSecrets(a), withoutEmbedSecrets(b))
+}
+
+// withoutEmbedSecrets returns a copy of the field with null embedding model
+// secrets.
+func withoutEmbedSecrets(field CollectionResourceFieldModel) CollectionResourceFieldModel {
+	if field.Embed == nil || field.Embed.ModelConfig == nil {
+		return field
+	}
+
+	embed := *field.Embed
+	config := *embed.ModelConfig
+	config.AccessToken = types.StringNull()
+	config.ApiKey = types.StringNull()
+	config.ClientSecret = types.StringNull()
+	config.RefreshToken = types.StringNull()
+
+	embed.ModelConfig = &config
+	field.Embed = &embed
+
+	return field
+}
+
+// embedSecretsChanged reports whether the planned field sets embedding model
+// secrets which differ from the prior ones, e.g. a rotated API key. Secrets
+// removed from the configuration are kept by Typesense and not reported.
+func embedSecretsChanged(prior, planned CollectionResourceFieldModel) bool {
+	if planned.Embed == nil || planned.Embed.ModelConfig == nil {
+		return false
+	}
+
+	plannedConfig := planned.Embed.ModelConfig
+	priorConfig := &CollectionFieldEmbedModelConfigModel{}
+	if prior.Embed != nil && prior.Embed.ModelConfig != nil {
+		priorConfig = prior.Embed.ModelConfig
+	}
+
+	changed := func(planned, prior types.String) bool {
+		return !planned.IsNull() && !planned.Equal(prior)
+	}
+
+	return changed(plannedConfig.AccessToken, priorConfig.AccessToken) ||
+		changed(plannedConfig.ApiKey, priorConfig.ApiKey) ||
+		changed(plannedConfig.ClientSecret, priorConfig.ClientSecret) ||
+		changed(plannedConfig.RefreshToken, priorConfig.RefreshToken)
 }
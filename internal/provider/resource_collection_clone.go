@@ -0,0 +1,200 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/typesense/typesense-go/v3/typesense/api"
+)
+
+// cloneCollection creates the physical collection from source_collection and
+// applies the declared fields on top of the cloned ones. The clone is deleted
+// again if any step fails, so that a retry starts from scratch.
+func (r *CollectionResource) cloneCollection(ctx context.Context, data CollectionResourceModel, name string, diags *diag.Diagnostics) *collectionResponseAPI {
+	source := data.SourceCollection.ValueString()
+	tflog.Info(ctx, "###Cloning collection "+source+" into "+name)
+
+	collection, err := r.client.cloneCollection(ctx, source, name)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to clone collection %q, got error: %s", source, err))
+		return nil
+	}
+
+	cleanup := func() {
+		if _, err := r.client.Collection(name).Delete(ctx); err != nil {
+			tflog.Warn(ctx, "###Unable to delete collection "+name+": "+err.Error())
+		}
+	}
+
+	if mismatches := clonedSettingsMismatches(collection, data); len(mismatches) > 0 {
+		cleanup()
+		diags.AddError(
+			"Cloned collection settings differ",
+			fmt.Sprintf("Collection %q has different %s, which cannot be changed on the cloned collection. Set them to the values of the source collection.", source, strings.Join(mismatches, ", ")),
+		)
+		return nil
+	}
+
+	if !data.CopySynonyms.ValueBool() {
+		if err := r.deleteCollectionSynonyms(ctx, name); err != nil {
+			cleanup()
+			diags.AddError("Client Error", fmt.Sprintf("Unable to delete synonyms of cloned collection, got error: %s", err))
+			return nil
+		}
+	}
+
+	if !data.CopyOverrides.ValueBool() {
+		if err := r.deleteCollectionOverrides(ctx, name); err != nil {
+			cleanup()
+			diags.AddError("Client Error", fmt.Sprintf("Unable to delete overrides of cloned collection, got error: %s", err))
+			return nil
+		}
+	}
+
+	schema, err := clonedCollectionUpdate(ctx, collection, data)
+	if err != nil {
+		cleanup()
+		diags.AddError("JSON format error", fmt.Sprintf("Unable to parse collection metadata, got error: %s", err))
+		return nil
+	}
+
+	if len(schema.Fields) == 0 && schema.Metadata == nil {
+		return collection
+	}
+
+	if !r.alterCollection(ctx, name, schema, diags) {
+		cleanup()
+		return nil
+	}
+
+	collection, err = r.client.retrieveCollection(ctx, name)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to retrieve cloned collection, got error: %s", err))
+		return nil
+	}
+
+	return collection
+}
+
+// clonedSettingsMismatches returns the settings Typesense cannot alter that
+// differ between the cloned collection and the configuration.
+func clonedSettingsMismatches(collection *collectionResponseAPI, data CollectionResourceModel) []string {
+	mismatches := []string{}
+
+	defaultSortingField := ""
+	if collection.DefaultSortingField != nil {
+		defaultSortingField = *collection.DefaultSortingField
+	}
+	if defaultSortingField != data.DefaultSortingField.ValueString() {
+		mismatches = append(mismatches, "default_sorting_field")
+	}
+
+	if collection.EnableNestedFields != nil && *collection.EnableNestedFields != data.EnableNestedFields.ValueBool() {
+		mismatches = append(mismatches, "enable_nested_fields")
+	}
+
+	symbolsToIndex := []string{}
+	if collection.SymbolsToIndex != nil {
+		symbolsToIndex = *collection.SymbolsToIndex
+	}
+	if !slices.Equal(symbolsToIndex, convertTerraformArrayToStringArray(data.SymbolsToIndex)) {
+		mismatches = append(mismatches, "symbols_to_index")
+	}
+
+	tokenSeparators := []string{}
+	if collection.TokenSeparators != nil {
+		tokenSeparators = *collection.TokenSeparators
+	}
+	if !slices.Equal(tokenSeparators, convertTerraformArrayToStringArray(data.TokenSeparators)) {
+		mismatches = append(mismatches, "token_separators")
+	}
+
+	return mismatches
+}
+
+// clonedCollectionUpdate returns the changes adding the declared fields and
+// metadata to a cloned collection. Cloned fields with the same name are
+// dropped and added again, the other cloned fields are kept.
+func clonedCollectionUpdate(ctx context.Context, collection *collectionResponseAPI, data CollectionResourceModel) (*collectionUpdateSchemaAPI, error) {
+	schema := &collectionUpdateSchemaAPI{}
+
+	clonedFields := map[string]CollectionResourceFieldModel{}
+	for _, field := range flattenCollectionFields(collection.Fields) {
+		clonedFields[field.Name.ValueString()] = field
+	}
+
+	drop := true
+
+	for _, field := range data.Fields {
+		cloned, ok := clonedFields[field.Name.ValueString()]
+
+		if !ok {
+			schema.Fields = append(schema.Fields, filedModelToApiField(field))
+			tflog.Info(ctx, "###Field will be created: "+field.Name.ValueString())
+		} else if !fieldsEqual(cloned, field) {
+			schema.Fields = append(schema.Fields,
+				collectionFieldAPI{Field: api.Field{
+					Drop: &drop,
+					Name: field.Name.ValueString(),
+				}},
+				filedModelToApiField(field))
+			tflog.Info(ctx, "###Field will be updated: "+field.Name.ValueString())
+		} else if embedSecretsChanged(cloned, field) {
+			schema.Fields = append(schema.Fields, filedModelToApiField(field))
+			tflog.Info(ctx, "###Field embedding model secrets will be updated: "+field.Name.ValueString())
+		}
+	}
+
+	metadata, err := flattenCollectionMetadata(collection.Metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	if !data.Metadata.Equal(metadata) {
+		values := map[string]interface{}{}
+		if !data.Metadata.IsNull() {
+			values, err = parseJsonStringToMap(data.Metadata.ValueString())
+			if err != nil {
+				return nil, err
+			}
+		}
+		schema.Metadata = &values
+	}
+
+	return schema, nil
+}
+
+func (r *CollectionResource) deleteCollectionSynonyms(ctx context.Context, name string) error {
+	synonyms, err := r.client.Collection(name).Synonyms().Retrieve(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, synonym := range synonyms {
+		if _, err := r.client.Collection(name).Synonym(*synonym.Id).Delete(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *CollectionResource) deleteCollectionOverrides(ctx context.Context, name string) error {
+	overrides, err := r.client.Collection(name).Overrides().Retrieve(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, override := range overrides {
+		if _, err := r.client.Collection(name).Override(*override.Id).Delete(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/typesense/typesense-go/v3/typesense/api"
+)
+
+func TestAccCollectionResource_SourceCollection(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCollectionResourceConfigSourceCollection("test_collection_clone"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("typesense_collection.clone", "name", "test_collection_clone"),
+					resource.TestCheckResourceAttr("typesense_collection.clone", "source_collection", "test_collection_clone_source"),
+					resource.TestCheckResourceAttr("typesense_collection.clone", "default_sorting_field", "points"),
+					resource.TestCheckResourceAttr("typesense_collection.clone", "fields.#", "1"),
+					resource.TestCheckResourceAttr("typesense_collection.clone", "fields.0.name", "preview_note"),
+				),
+			},
+			{
+				Config:   testAccCollectionResourceConfigSourceCollection("test_collection_clone"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testAccCollectionResourceConfigSourceCollection(name string) string {
+	return fmt.Sprintf(`
+resource "typesense_collection" "source" {
+  name = "test_collection_clone_source"
+
+  fields {
+    name = "title"
+    type = "string"
+  }
+
+  fields {
+    name = "points"
+    type = "int32"
+  }
+
+  default_sorting_field = "points"
+}
+
+resource "typesense_collection" "clone" {
+  name              = %[1]q
+  source_collection = typesense_collection.source.name
+  copy_synonyms     = false
+
+  fields {
+    name     = "preview_note"
+    type     = "string"
+    optional = true
+  }
+
+  default_sorting_field = "points"
+}
+`, name)
+}
+
+func TestClonedSettingsMismatches(t *testing.T) {
+	sortingField := "points"
+	nested := true
+
+	collection := &collectionResponseAPI{CollectionResponse: api.CollectionResponse{
+		DefaultSortingField: &sortingField,
+		EnableNestedFields:  &nested,
+		SymbolsToIndex:      &[]string{"+"},
+	}}
+
+	data := CollectionResourceModel{
+		DefaultSortingField: types.StringValue("points"),
+		EnableNestedFields:  types.BoolValue(false),
+		SymbolsToIndex:      []types.String{types.StringValue("+")},
+		TokenSeparators:     []types.String{types.StringValue("-")},
+	}
+
+	mismatches := clonedSettingsMismatches(collection, data)
+
+	if !slices.Equal(mismatches, []string{"enable_nested_fields", "token_separators"}) {
+		t.Errorf("expected enable_nested_fields and token_separators mismatches, got %v", mismatches)
+	}
+}
+
+func TestClonedCollectionUpdate(t *testing.T) {
+	cloned := []collectionFieldAPI{
+		{Field: api.Field{Name: "title", Type: "string"}},
+		{Field: api.Field{Name: "points", Type: "int32"}},
+	}
+
+	fields := flattenCollectionFields(cloned)
+	points := fields[1]
+	points.Facet = types.BoolValue(true)
+
+	note := fields[0]
+	note.Name = types.StringValue("note")
+
+	data := CollectionResourceModel{
+		Fields:   []CollectionResourceFieldModel{fields[0], points, note},
+		Metadata: jsontypes.NewNormalizedValue(`{"env":"preview"}`),
+	}
+
+	schema, err := clonedCollectionUpdate(context.Background(), &collectionResponseAPI{Fields: cloned}, data)
+	if err != nil {
+		t.Fatalf("unable to build update: %s", err)
+	}
+
+	names := []string{}
+	for _, field := range schema.Fields {
+		names = append(names, field.Name)
+	}
+
+	// title is unchanged, points is dropped and added again, note is added
+	if !slices.Equal(names, []string{"points", "points", "note"}) {
+		t.Errorf("expected points to be replaced and note added, got %v", names)
+	}
+
+	if schema.Fields[0].Drop == nil || !*schema.Fields[0].Drop {
+		t.Errorf("expected points to be dropped first")
+	}
+
+	if schema.Metadata == nil || (*schema.Metadata)["env"] != "preview" {
+		t.Errorf("expected metadata to be set, got %v", schema.Metadata)
+	}
+}
+
+func TestFlattenCollection_SourceCollection(t *testing.T) {
+	collection := &collectionResponseAPI{
+		Fields: []collectionFieldAPI{
+			{Field: api.Field{Name: "title", Type: "string"}},
+			{Field: api.Field{Name: "preview_note", Type: "string"}},
+		},
+	}
+
+	data := CollectionResourceModel{
+		SourceCollection: types.StringValue("products"),
+		Fields:           []CollectionResourceFieldModel{{Name: types.StringValue("preview_note")}},
+	}
+
+	if diags := flattenCollection(collection, &data); diags.HasError() {
+		t.Fatalf("unable to flatten collection: %v", diags)
+	}
+
+	// The title field inherited from the source collection is left out
+	if len(data.Fields) != 1 || data.Fields[0].Name.ValueString() != "preview_note" {
+		t.Errorf("expected only the declared preview_note field, got %v", data.Fields)
+	}
+}
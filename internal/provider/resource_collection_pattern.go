@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// Characters making a field name a regular expression. Dots are left out as
+// they also separate the names of nested fields.
+const patternFieldNameCharacters = `*+?[]()|^$\{}`
+
+// isPatternFieldName reports whether the field name is a regular expression,
+// e.g. .* or .*_facet, declaring the type of all the fields it matches.
+func isPatternFieldName(name string) bool {
+	return strings.ContainsAny(name, patternFieldNameCharacters)
+}
+
+// compilePatternFieldName compiles a pattern field name, which Typesense
+// matches against whole field names.
+func compilePatternFieldName(name string) (*regexp.Regexp, error) {
+	return regexp.Compile("^(?:" + name + ")$")
+}
+
+// validatePatternField checks the attributes of a pattern field: the catch-all
+// .* pattern only supports the auto and string* types, and patterns must be
+// optional and cannot hold embeddings or references.
+func validatePatternField(field CollectionResourceFieldModel, fieldPath path.Path, diags *diag.Diagnostics) {
+	if field.Name.IsUnknown() || !isPatternFieldName(field.Name.ValueString()) {
+		return
+	}
+
+	name := field.Name.ValueString()
+
+	if _, err := compilePatternFieldName(name); err != nil {
+		diags.AddAttributeError(
+			fieldPath.AtName("name"),
+			"Invalid pattern field",
+			fmt.Sprintf("Field name %q is not a valid regular expression, got error: %s", name, err),
+		)
+		return
+	}
+
+	if name == ".*" && !field.Type.IsUnknown() && field.Type.ValueString() != "auto" && field.Type.ValueString() != "string*" {
+		diags.AddAttributeError(
+			fieldPath.AtName("type"),
+			"Invalid pattern field",
+			fmt.Sprintf("Field %q matches all fields, its type must be auto or string*, got %q.", name, field.Type.ValueString()),
+		)
+	}
+
+	if !field.Optional.IsUnknown() && !field.Optional.IsNull() && !field.Optional.ValueBool() {
+		diags.AddAttributeError(
+			fieldPath.AtName("optional"),
+			"Invalid pattern field",
+			fmt.Sprintf("Field %q is a pattern, documents may not have any matching field so it must be optional.", name),
+		)
+	}
+
+	if field.Embed != nil || !field.NumDim.IsNull() || !field.Reference.IsNull() {
+		diags.AddAttributeError(
+			fieldPath,
+			"Invalid pattern field",
+			fmt.Sprintf("Field %q is a pattern, it cannot set embed, num_dim or reference.", name),
+		)
+	}
+}
+
+// filterPatternMatchedFields leaves out the fields Typesense created for
+// documents matching a declared pattern field, unless they are declared too.
+func filterPatternMatchedFields(fields []CollectionResourceFieldModel, declared []CollectionResourceFieldModel) []CollectionResourceFieldModel {
+	names := make(map[string]bool, len(declared))
+	for _, field := range declared {
+		names[field.Name.ValueString()] = true
+	}
+
+	patterns := declaredFieldPatterns(declared)
+
+	if len(patterns) == 0 {
+		return fields
+	}
+
+	filtered := make([]CollectionResourceFieldModel, 0, len(fields))
+
+	for _, field := range fields {
+		name := field.Name.ValueString()
+
+		if !names[name] && matchesAnyPattern(name, patterns) {
+			continue
+		}
+
+		filtered = append(filtered, field)
+	}
+
+	return filtered
+}
+
+// declaredFieldPatterns compiles the names of the declared pattern fields.
+func declaredFieldPatterns(declared []CollectionResourceFieldModel) []*regexp.Regexp {
+	patterns := []*regexp.Regexp{}
+
+	for _, field := range declared {
+		if isPatternFieldName(field.Name.ValueString()) {
+			if pattern, err := compilePatternFieldName(field.Name.ValueString()); err == nil {
+				patterns = append(patterns, pattern)
+			}
+		}
+	}
+
+	return patterns
+}
+
+func matchesAnyPattern(name string, patterns []*regexp.Regexp) bool {
+	for _, pattern := range patterns {
+		if pattern.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCollectionResource_PatternFields(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCollectionResourceConfigPatternFields("test_collection_patterns", "string"),
+				ExpectError: regexp.MustCompile(`its type must be auto or string\*`),
+			},
+			{
+				Config: testAccCollectionResourceConfigPatternFields("test_collection_patterns", "auto"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("typesense_collection.test", "fields.#", "3"),
+					resource.TestCheckResourceAttr("typesense_collection.test", "fields.1.name", ".*_facet"),
+					resource.TestCheckResourceAttr("typesense_collection.test", "fields.1.optional", "true"),
+					resource.TestCheckResourceAttr("typesense_collection.test", "fields.2.name", ".*"),
+					resource.TestCheckResourceAttr("typesense_collection.test", "fields.2.optional", "true"),
+				),
+			},
+			// With the default managed_fields all, the fields created for the
+			// document are read and planned to be dropped
+			{
+				Config:             testAccCollectionResourceConfigPatternFields("test_collection_patterns", "auto"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccCollectionResourceConfigPatternFields(name string, catchAllType string) string {
+	return fmt.Sprintf(`
+resource "typesense_collection" "test" {
+  name = %[1]q
+
+  fields {
+    name = "title"
+    type = "string"
+  }
+
+  fields {
+    name  = ".*_facet"
+    type  = "string"
+    facet = true
+  }
+
+  fields {
+    name = ".*"
+    type = %[2]q
+  }
+}
+
+resource "typesense_document" "test" {
+  name            = "doc1"
+  collection_name = typesense_collection.test.name
+  document = jsonencode({
+    title       = "Shoe"
+    brand_facet = "Acme"
+    rating      = 4
+  })
+}
+`, name, catchAllType)
+}
+
+func TestIsPatternFieldName(t *testing.T) {
+	tests := map[string]bool{
+		".*":           true,
+		".*_facet":     true,
+		"num_[0-9]+":   true,
+		"title":        false,
+		"address.city": false,
+	}
+
+	for name, expected := range tests {
+		if got := isPatternFieldName(name); got != expected {
+			t.Errorf("expected isPatternFieldName(%q) to be %t, got %t", name, expected, got)
+		}
+	}
+}
+
+func TestFilterPatternMatchedFields(t *testing.T) {
+	field := func(name string) CollectionResourceFieldModel {
+		return CollectionResourceFieldModel{Name: types.StringValue(name)}
+	}
+
+	declared := []CollectionResourceFieldModel{field("title"), field(".*_facet"), field("brand_facet")}
+	fields := []CollectionResourceFieldModel{field("title"), field(".*_facet"), field("brand_facet"), field("color_facet"), field("rating")}
+
+	filtered := filterPatternMatchedFields(fields, declared)
+
+	expected := []string{"title", ".*_facet", "brand_facet", "rating"}
+	if len(filtered) != len(expected) {
+		t.Fatalf("expected %d fields, got %d", len(expected), len(filtered))
+	}
+	for i, name := range expected {
+		if filtered[i].Name.ValueString() != name {
+			t.Errorf("expected field %d to be %q, got %q", i, name, filtered[i].Name.ValueString())
+		}
+	}
+}
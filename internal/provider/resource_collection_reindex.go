@@ -0,0 +1,232 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/typesense/typesense-go/v3/typesense/api"
+)
+
+const requiresReplaceUnlessReindexViaAliasDescription = "Changing it recreates the collection, unless field_change_strategy is reindex_via_alias."
+
+var physicalCollectionVersionRegexp = regexp.MustCompile(`^_v(\d+)$`)
+
+// isReindexViaAlias reports whether the collection is served through an alias
+// pointing to a versioned physical collection.
+func isReindexViaAlias(data CollectionResourceModel) bool {
+	return data.FieldChangeStrategy.ValueString() == fieldChangeStrategyReindexViaAlias
+}
+
+// collectionPhysicalName returns the name of the collection holding the
+// documents, falling back to the id for states without physical_name.
+func collectionPhysicalName(data CollectionResourceModel) string {
+	if data.PhysicalName.IsNull() || data.PhysicalName.IsUnknown() || data.PhysicalName.ValueString() == "" {
+		return data.Id.ValueString()
+	}
+	return data.PhysicalName.ValueString()
+}
+
+// requiresReplaceUnlessReindexViaAlias reports whether a change to a setting
+// Typesense cannot alter requires replacing the collection. Collections
+// served through an alias are copied into a new version by Update instead.
+func requiresReplaceUnlessReindexViaAlias(ctx context.Context, state tfsdk.State, plan tfsdk.Plan) (bool, diag.Diagnostics) {
+	var stateStrategy, planStrategy types.String
+
+	diags := state.GetAttribute(ctx, path.Root("field_change_strategy"), &stateStrategy)
+	diags.Append(plan.GetAttribute(ctx, path.Root("field_change_strategy"), &planStrategy)...)
+
+	return stateStrategy.ValueString() != fieldChangeStrategyReindexViaAlias || planStrategy.ValueString() != fieldChangeStrategyReindexViaAlias, diags
+}
+
+func requiresReplaceUnlessReindexViaAliasString(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	requiresReplace, diags := requiresReplaceUnlessReindexViaAlias(ctx, req.State, req.Plan)
+	resp.RequiresReplace = requiresReplace
+	resp.Diagnostics.Append(diags...)
+}
+
+func requiresReplaceUnlessReindexViaAliasList(ctx context.Context, req planmodifier.ListRequest, resp *listplanmodifier.RequiresReplaceIfFuncResponse) {
+	requiresReplace, diags := requiresReplaceUnlessReindexViaAlias(ctx, req.State, req.Plan)
+	resp.RequiresReplace = requiresReplace
+	resp.Diagnostics.Append(diags...)
+}
+
+// reindexReasons lists the changes forcing a collection served through an
+// alias to be copied into a new version.
+func reindexReasons(state CollectionResourceModel, plan CollectionResourceModel) []string {
+	reasons := []string{}
+
+	if !plan.Name.Equal(state.Name) {
+		reasons = append(reasons, "name")
+	}
+
+	if !plan.DefaultSortingField.Equal(state.DefaultSortingField) {
+		reasons = append(reasons, "default_sorting_field")
+	}
+
+	if !slices.Equal(convertTerraformArrayToStringArray(plan.SymbolsToIndex), convertTerraformArrayToStringArray(state.SymbolsToIndex)) {
+		reasons = append(reasons, "symbols_to_index")
+	}
+
+	if !slices.Equal(convertTerraformArrayToStringArray(plan.TokenSeparators), convertTerraformArrayToStringArray(state.TokenSeparators)) {
+		reasons = append(reasons, "token_separators")
+	}
+
+	if changed := changedCollectionFields(state.Fields, plan.Fields); len(changed) > 0 {
+		reasons = append(reasons, `fields "`+strings.Join(changed, `", "`)+`"`)
+	}
+
+	return reasons
+}
+
+func physicalCollectionName(name string, version int) string {
+	return fmt.Sprintf("%s_v%d", name, version)
+}
+
+// nextPhysicalCollectionName returns the name of the version following the
+// current physical collection, e.g. products_v3 after products_v2.
+func nextPhysicalCollectionName(name string, current string) string {
+	version := 1
+
+	if match := physicalCollectionVersionRegexp.FindStringSubmatch(strings.TrimPrefix(current, name)); match != nil {
+		if currentVersion, err := strconv.Atoi(match[1]); err == nil {
+			version = currentVersion
+		}
+	}
+
+	return physicalCollectionName(name, version+1)
+}
+
+// reindexCollection creates the next physical version of a collection served
+// through an alias with the planned schema and settings, copies the documents,
+// synonyms and overrides into it, points the alias to it and deletes the
+// previous version. Searches
+// keep using the previous version until the alias is switched. Typesense
+// cannot freeze writes, documents written to the previous version during the
+// copy are not in the new one. The previous version is backed up following
+// backup_on_destroy before it is deleted, and kept with deletion_protection.
+// When the collection is renamed, the new version is served by a new alias
+// and the previous alias is deleted. It returns nil when the new version
+// could not be put in service.
+func (r *CollectionResource) reindexCollection(ctx context.Context, plan CollectionResourceModel, state CollectionResourceModel, diags *diag.Diagnostics) *collectionResponseAPI {
+	current := collectionPhysicalName(state)
+	next := nextPhysicalCollectionName(plan.Name.ValueString(), current)
+
+	renamed := plan.Name.ValueString() != state.Name.ValueString()
+	if renamed {
+		next = physicalCollectionName(plan.Name.ValueString(), 1)
+	}
+
+	schema, err := collectionModelToSchema(plan, next)
+	if err != nil {
+		diags.AddError("JSON format error", fmt.Sprintf("Unable to parse collection metadata, got error: %s", err))
+		return nil
+	}
+
+	tflog.Info(ctx, "###Reindex collection "+current+" into "+next)
+
+	if _, err := r.client.createCollection(ctx, schema); err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to create collection %s, got error: %s", next, err))
+		return nil
+	}
+
+	cleanup := func() {
+		if _, err := r.client.Collection(next).Delete(ctx); err != nil {
+			tflog.Warn(ctx, "###Unable to delete collection "+next+": "+err.Error())
+		}
+	}
+
+	if err := r.client.copyCollectionDocuments(ctx, current, next); err != nil {
+		cleanup()
+		diags.AddError("Client Error", fmt.Sprintf("Unable to copy documents from %s to %s, got error: %s", current, next, err))
+		return nil
+	}
+
+	// Synonyms and overrides are addressed through the alias, they would be
+	// deleted along with the previous version
+	if err := r.client.copyCollectionSynonyms(ctx, current, next); err != nil {
+		cleanup()
+		diags.AddError("Client Error", fmt.Sprintf("Unable to copy synonyms from %s to %s, got error: %s", current, next, err))
+		return nil
+	}
+
+	if err := r.client.copyCollectionOverrides(ctx, current, next); err != nil {
+		cleanup()
+		diags.AddError("Client Error", fmt.Sprintf("Unable to copy overrides from %s to %s, got error: %s", current, next, err))
+		return nil
+	}
+
+	if _, err := r.client.Aliases().Upsert(ctx, plan.Name.ValueString(), &api.CollectionAliasSchema{CollectionName: next}); err != nil {
+		cleanup()
+		diags.AddError("Client Error", fmt.Sprintf("Unable to point alias %s to %s, got error: %s", plan.Name.ValueString(), next, err))
+		return nil
+	}
+
+	tflog.Info(ctx, "###Alias "+plan.Name.ValueString()+" points to "+next)
+
+	if renamed {
+		if _, err := r.client.Alias(state.Name.ValueString()).Delete(ctx); err != nil {
+			diags.AddWarning(
+				"Previous alias not deleted",
+				fmt.Sprintf("Alias %s now points to %s, but the previous alias %s could not be deleted, got error: %s", plan.Name.ValueString(), next, state.Name.ValueString(), err),
+			)
+		}
+	}
+
+	r.deletePreviousCollection(ctx, plan.Name.ValueString(), current, next, state, diags)
+
+	collection, err := r.client.retrieveCollection(ctx, next)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to retrieve collection %s, got error: %s", next, err))
+		return nil
+	}
+
+	return collection
+}
+
+// deletePreviousCollection deletes the physical collection a reindex replaced,
+// the same way Delete would: it is kept with deletion_protection, and backed
+// up first with backup_on_destroy. The new version is already in service, so
+// failures are reported as warnings and leave the previous collection in place.
+func (r *CollectionResource) deletePreviousCollection(ctx context.Context, alias string, current string, next string, state CollectionResourceModel, diags *diag.Diagnostics) {
+	if state.DeletionProtection.ValueBool() {
+		diags.AddWarning(
+			"Previous collection not deleted",
+			fmt.Sprintf("Alias %s now points to %s. The previous collection %s is kept as deletion_protection is set to true, delete it once it is no longer needed.", alias, next, current),
+		)
+		return
+	}
+
+	if state.BackupOnDestroy != nil {
+		backupPath := state.BackupOnDestroy.Path.ValueString()
+
+		tflog.Info(ctx, "###Backup collection "+current+" to "+backupPath)
+
+		if err := r.client.backupCollectionDocuments(ctx, current, backupPath, state.BackupOnDestroy.Gzip.ValueBool()); err != nil {
+			diags.AddWarning(
+				"Previous collection not deleted",
+				fmt.Sprintf("Alias %s now points to %s, but the previous collection %s could not be exported to %s and is kept, got error: %s", alias, next, current, backupPath, err),
+			)
+			return
+		}
+	}
+
+	if _, err := r.client.Collection(current).Delete(ctx); err != nil {
+		diags.AddWarning(
+			"Previous collection not deleted",
+			fmt.Sprintf("Alias %s now points to %s, but the previous collection %s could not be deleted, got error: %s", alias, next, current, err),
+		)
+	}
+}
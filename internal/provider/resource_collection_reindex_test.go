@@ -0,0 +1,278 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNextPhysicalCollectionName(t *testing.T) {
+	tests := map[string]struct {
+		current  string
+		expected string
+	}{
+		"unversioned":   {current: "products", expected: "products_v2"},
+		"first version": {current: "products_v1", expected: "products_v2"},
+		"later version": {current: "products_v12", expected: "products_v13"},
+		"other suffix":  {current: "products_v1_old", expected: "products_v2"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := nextPhysicalCollectionName("products", test.current); got != test.expected {
+				t.Errorf("expected %q, got %q", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestCheckImportResult(t *testing.T) {
+	if err := checkImportResult(strings.NewReader("{\"success\":true}\n{\"success\":true}\n")); err != nil {
+		t.Errorf("expected no error, got %s", err)
+	}
+
+	err := checkImportResult(strings.NewReader("{\"success\":true}\n{\"success\":false,\"error\":\"Field `price` must be an int64.\",\"document\":\"{}\"}\n{\"success\":false,\"error\":\"other\"}\n"))
+	if err == nil || !strings.Contains(err.Error(), "2 documents were rejected") || !strings.Contains(err.Error(), "must be an int64") {
+		t.Errorf("expected rejected documents error, got %v", err)
+	}
+}
+
+func TestReindexReasons(t *testing.T) {
+	state := CollectionResourceModel{
+		Name:                types.StringValue("products"),
+		DefaultSortingField: types.StringValue("price"),
+		SymbolsToIndex:      []types.String{},
+		TokenSeparators:     []types.String{types.StringValue("-")},
+		Fields:              []CollectionResourceFieldModel{{Name: types.StringValue("price"), Type: types.StringValue("int32")}},
+	}
+
+	if reasons := reindexReasons(state, state); len(reasons) != 0 {
+		t.Errorf("expected no reasons for an unchanged collection, got %v", reasons)
+	}
+
+	plan := state
+	plan.DefaultSortingField = types.StringValue("rating")
+	plan.TokenSeparators = []types.String{types.StringValue("/")}
+	plan.Fields = []CollectionResourceFieldModel{{Name: types.StringValue("price"), Type: types.StringValue("int64")}}
+
+	expected := []string{"default_sorting_field", "token_separators", `fields "price"`}
+	if reasons := reindexReasons(state, plan); !slices.Equal(reasons, expected) {
+		t.Errorf("expected %v, got %v", expected, reasons)
+	}
+}
+
+func TestDeletePreviousCollection(t *testing.T) {
+	tests := map[string]struct {
+		protected    bool
+		backup       bool
+		exportStatus int
+		expectDelete bool
+	}{
+		"unprotected":        {expectDelete: true},
+		"protected":          {protected: true},
+		"backed up":          {backup: true, exportStatus: http.StatusOK, expectDelete: true},
+		"backup export fail": {backup: true, exportStatus: http.StatusInternalServerError},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			deleted := false
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/collections/products_v1/documents/export":
+					w.WriteHeader(test.exportStatus)
+					_, _ = io.WriteString(w, testBackupDocuments)
+				case r.Method == http.MethodDelete && r.URL.Path == "/collections/products_v1":
+					deleted = true
+					w.Header().Set("Content-Type", "application/json")
+					_, _ = io.WriteString(w, `{"name": "products_v1"}`)
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			t.Cleanup(server.Close)
+
+			backupPath := filepath.Join(t.TempDir(), "products.jsonl")
+			state := CollectionResourceModel{DeletionProtection: types.BoolValue(test.protected)}
+			if test.backup {
+				state.BackupOnDestroy = &CollectionBackupOnDestroyModel{Path: types.StringValue(backupPath), Gzip: types.BoolValue(false)}
+			}
+
+			r := &CollectionResource{client: testTypesenseClient(t, server.URL)}
+
+			var diags diag.Diagnostics
+			r.deletePreviousCollection(context.Background(), "products", "products_v1", "products_v2", state, &diags)
+
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			if deleted != test.expectDelete {
+				t.Errorf("expected delete %t, got %t", test.expectDelete, deleted)
+			}
+
+			if !test.expectDelete && diags.WarningsCount() == 0 {
+				t.Errorf("expected a warning for the kept collection")
+			}
+
+			if test.backup && test.expectDelete {
+				if content, err := os.ReadFile(backupPath); err != nil || string(content) != testBackupDocuments {
+					t.Errorf("expected the previous collection to be backed up, got %q, %v", content, err)
+				}
+			}
+		})
+	}
+}
+
+func TestCollectionRead_MissingAlias(t *testing.T) {
+	tests := map[string]struct {
+		collectionExists bool
+	}{
+		"collection kept":    {collectionExists: true},
+		"collection deleted": {},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet && r.URL.Path == "/collections/products_v2" && test.collectionExists {
+					w.Header().Set("Content-Type", "application/json")
+					_, _ = io.WriteString(w, `{"name": "products_v2", "fields": [{"name": "title", "type": "string"}]}`)
+					return
+				}
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = io.WriteString(w, `{"message": "Not Found"}`)
+			}))
+			t.Cleanup(server.Close)
+
+			r := &CollectionResource{client: testTypesenseClient(t, server.URL)}
+			schema, value := testResourceValue(t, r, `{"id": "products", "name": "products", "physical_name": "products_v2", "field_change_strategy": "reindex_via_alias"}`)
+
+			req := resource.ReadRequest{State: tfsdk.State{Schema: schema, Raw: value}}
+			resp := &resource.ReadResponse{State: tfsdk.State{Schema: schema, Raw: value}}
+
+			r.Read(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+
+			if !test.collectionExists {
+				if !resp.State.Raw.IsNull() {
+					t.Errorf("expected the deleted collection to be removed from the state")
+				}
+				return
+			}
+
+			var data CollectionResourceModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+
+			if data.PhysicalName.ValueString() != "products_v2" || len(data.Fields) != 1 {
+				t.Errorf("expected the collection to be read from its physical name, got %v", data)
+			}
+
+			if resp.Diagnostics.WarningsCount() != 1 {
+				t.Errorf("expected a warning for the missing alias, got %v", resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestReindexCollection_Synonyms(t *testing.T) {
+	tests := map[string]struct {
+		upsertStatus int
+		expectError  bool
+	}{
+		"copied":      {upsertStatus: http.StatusOK},
+		"copy failed": {upsertStatus: http.StatusInternalServerError, expectError: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var copiedSynonym string
+			aliasSwitched, nextDeleted := false, false
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+
+				switch {
+				case r.Method == http.MethodPost && r.URL.Path == "/collections":
+					w.WriteHeader(http.StatusCreated)
+					_, _ = io.WriteString(w, `{"name": "products_v2"}`)
+				case r.Method == http.MethodGet && r.URL.Path == "/collections/products_v1/documents/export":
+					_, _ = io.WriteString(w, testBackupDocuments)
+				case r.Method == http.MethodPost && r.URL.Path == "/collections/products_v2/documents/import":
+					_, _ = io.WriteString(w, "{\"success\":true}\n{\"success\":true}\n")
+				case r.Method == http.MethodGet && r.URL.Path == "/collections/products_v1/synonyms":
+					_, _ = io.WriteString(w, `{"synonyms": [{"id": "shoes", "synonyms": ["shoe", "sneaker"]}]}`)
+				case r.Method == http.MethodPut && r.URL.Path == "/collections/products_v2/synonyms/shoes":
+					body, _ := io.ReadAll(r.Body)
+					copiedSynonym = string(body)
+					w.WriteHeader(test.upsertStatus)
+					_, _ = io.WriteString(w, `{"id": "shoes", "synonyms": ["shoe", "sneaker"]}`)
+				case r.Method == http.MethodGet && r.URL.Path == "/collections/products_v1/overrides":
+					_, _ = io.WriteString(w, `{"overrides": []}`)
+				case r.Method == http.MethodPut && r.URL.Path == "/aliases/products":
+					aliasSwitched = true
+					_, _ = io.WriteString(w, `{"name": "products", "collection_name": "products_v2"}`)
+				case r.Method == http.MethodDelete && r.URL.Path == "/collections/products_v2":
+					nextDeleted = true
+					_, _ = io.WriteString(w, `{"name": "products_v2"}`)
+				case r.Method == http.MethodDelete && r.URL.Path == "/collections/products_v1":
+					_, _ = io.WriteString(w, `{"name": "products_v1"}`)
+				case r.Method == http.MethodGet && r.URL.Path == "/collections/products_v2":
+					_, _ = io.WriteString(w, `{"name": "products_v2", "fields": [{"name": "title", "type": "string"}]}`)
+				default:
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = io.WriteString(w, `{"message": "Not Found"}`)
+				}
+			}))
+			t.Cleanup(server.Close)
+
+			state := CollectionResourceModel{
+				Name:                types.StringValue("products"),
+				PhysicalName:        types.StringValue("products_v1"),
+				FieldChangeStrategy: types.StringValue(fieldChangeStrategyReindexViaAlias),
+				DeletionProtection:  types.BoolValue(false),
+			}
+			plan := state
+			plan.Fields = []CollectionResourceFieldModel{{Name: types.StringValue("title"), Type: types.StringValue("string")}}
+
+			r := &CollectionResource{client: testTypesenseClient(t, server.URL)}
+
+			var diags diag.Diagnostics
+			collection := r.reindexCollection(context.Background(), plan, state, &diags)
+
+			if !strings.Contains(copiedSynonym, `"sneaker"`) {
+				t.Errorf("expected the synonym to be copied into the new version, got %q", copiedSynonym)
+			}
+
+			if !test.expectError {
+				if diags.HasError() || collection == nil || !aliasSwitched {
+					t.Fatalf("expected the alias to be switched to the new version, got %v", diags)
+				}
+				return
+			}
+
+			if !diags.HasError() || collection != nil {
+				t.Fatalf("expected the failed synonym copy to abort the reindex")
+			}
+
+			if aliasSwitched || !nextDeleted {
+				t.Errorf("expected the alias to be kept and the new version deleted, got switched %t, deleted %t", aliasSwitched, nextDeleted)
+			}
+		})
+	}
+}
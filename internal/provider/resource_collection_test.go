@@ -1,11 +1,34 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"regexp"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
-
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+
+	"github.com/typesense/typesense-go/v3/typesense/api"
 )
 
 func TestAccCollectionResource(t *testing.T) {
@@ -848,3 +871,1588 @@ resource "typesense_collection" "test" {
 }
 `, name, protected)
 }
+
+func TestAccCollectionResource_ReferenceField(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCollectionResourceConfigReference("test_collection_books", "test_collection_authors", false),
+				ExpectError: regexp.MustCompile(`must have the "collection.field" format`),
+			},
+			{
+				Config: testAccCollectionResourceConfigReference("test_collection_books", "test_collection_authors.id", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("typesense_collection.test", "name", "test_collection_books"),
+					resource.TestCheckTypeSetElemNestedAttrs("typesense_collection.test", "fields.*", map[string]string{
+						"name":            "author_id",
+						"reference":       "test_collection_authors.id",
+						"async_reference": "true",
+					}),
+				),
+			},
+			{
+				ResourceName:            "typesense_collection.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"deletion_protection"},
+			},
+		},
+	})
+}
+
+func testAccCollectionResourceConfigReference(name string, reference string, asyncReference bool) string {
+	return fmt.Sprintf(`
+resource "typesense_collection" "authors" {
+  name = "test_collection_authors"
+
+  fields {
+    name = "name"
+    type = "string"
+  }
+}
+
+resource "typesense_collection" "test" {
+  name = %[1]q
+
+  fields {
+    name = "title"
+    type = "string"
+  }
+
+  fields {
+    name            = "author_id"
+    type            = "string"
+    reference       = %[2]q
+    async_reference = %[3]t
+  }
+
+  depends_on = [typesense_collection.authors]
+}
+`, name, reference, asyncReference)
+}
+
+func TestAccCollectionResource_VectorFieldHnswParams(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCollectionResourceConfigHnswParams("test_collection_hnsw", "string", "cosine"),
+				ExpectError: regexp.MustCompile(`can only be used on float\[\] fields`),
+			},
+			{
+				Config: testAccCollectionResourceConfigHnswParams("test_collection_hnsw", "float[]", "ip"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("typesense_collection.test", "name", "test_collection_hnsw"),
+					resource.TestCheckTypeSetElemNestedAttrs("typesense_collection.test", "fields.*", map[string]string{
+						"name":                        "embedding",
+						"vec_dist":                    "ip",
+						"hnsw_params.m":               "32",
+						"hnsw_params.ef_construction": "200",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("typesense_collection.test", "fields.*", map[string]string{
+						"name":     "title",
+						"vec_dist": "",
+					}),
+				),
+			},
+			{
+				ResourceName:            "typesense_collection.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"deletion_protection"},
+			},
+		},
+	})
+}
+
+func testAccCollectionResourceConfigHnswParams(name string, fieldType string, vecDist string) string {
+	return fmt.Sprintf(`
+resource "typesense_collection" "test" {
+  name = %[1]q
+
+  fields {
+    name = "title"
+    type = "string"
+  }
+
+  fields {
+    name     = "embedding"
+    type     = %[2]q
+    num_dim  = 768
+    vec_dist = %[3]q
+
+    hnsw_params = {
+      m = 32
+    }
+  }
+}
+`, name, fieldType, vecDist)
+}
+
+func TestAccCollectionResource_FieldRangeIndexSymbolsAndTokens(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCollectionResourceConfigFieldRangeIndexSymbolsTokens("test_collection_field_tokens"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("typesense_collection.test", "name", "test_collection_field_tokens"),
+					resource.TestCheckTypeSetElemNestedAttrs("typesense_collection.test", "fields.*", map[string]string{
+						"name":               "price",
+						"range_index":        "true",
+						"token_separators.#": "0",
+						"symbols_to_index.#": "0",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("typesense_collection.test", "fields.*", map[string]string{
+						"name":               "sku",
+						"range_index":        "false",
+						"token_separators.#": "1",
+						"token_separators.0": "-",
+						"symbols_to_index.#": "1",
+						"symbols_to_index.0": "+",
+					}),
+				),
+			},
+			// Refresh without changes must not produce a diff
+			{
+				Config:   testAccCollectionResourceConfigFieldRangeIndexSymbolsTokens("test_collection_field_tokens"),
+				PlanOnly: true,
+			},
+			{
+				ResourceName:            "typesense_collection.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"deletion_protection"},
+			},
+		},
+	})
+}
+
+func testAccCollectionResourceConfigFieldRangeIndexSymbolsTokens(name string) string {
+	return fmt.Sprintf(`
+resource "typesense_collection" "test" {
+  name = %[1]q
+
+  fields {
+    name        = "price"
+    type        = "float"
+    range_index = true
+  }
+
+  fields {
+    name             = "sku"
+    type             = "string"
+    token_separators = ["-"]
+    symbols_to_index = ["+"]
+  }
+}
+`, name)
+}
+
+func TestAccCollectionResource_Metadata(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCollectionResourceConfigMetadata("test_collection_metadata", "search", 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("typesense_collection.test", "name", "test_collection_metadata"),
+					resource.TestCheckResourceAttr("typesense_collection.test", "metadata", `{"owner":"search","schema_version":1}`),
+				),
+			},
+			// Update metadata in place
+			{
+				Config: testAccCollectionResourceConfigMetadata("test_collection_metadata", "catalog", 2),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("typesense_collection.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("typesense_collection.test", "metadata", `{"owner":"catalog","schema_version":2}`),
+				),
+			},
+			{
+				ResourceName:            "typesense_collection.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"deletion_protection"},
+			},
+		},
+	})
+}
+
+func testAccCollectionResourceConfigMetadata(name string, owner string, schemaVersion int) string {
+	return fmt.Sprintf(`
+resource "typesense_collection" "test" {
+  name = %[1]q
+
+  metadata = jsonencode({
+    owner          = %[2]q
+    schema_version = %[3]d
+  })
+
+  fields {
+    name = "title"
+    type = "string"
+  }
+}
+`, name, owner, schemaVersion)
+}
+
+func TestCollectionResource_UpgradeStateV0(t *testing.T) {
+	// Version 0 state as written by the first provider releases, with fields
+	// stored as a set, before metadata, reference and vector attributes.
+	rawState := `{
+  "default_sorting_field": "price",
+  "deletion_protection": true,
+  "enable_nested_fields": false,
+  "fields": [
+    {
+      "embed": null,
+      "facet": false,
+      "index": true,
+      "infix": false,
+      "locale": "",
+      "name": "title",
+      "num_dim": null,
+      "optional": false,
+      "sort": false,
+      "stem": false,
+      "stem_dictionary": "",
+      "store": true,
+      "type": "string"
+    },
+    {
+      "embed": null,
+      "facet": true,
+      "index": true,
+      "infix": false,
+      "locale": "",
+      "name": "price",
+      "num_dim": null,
+      "optional": false,
+      "sort": true,
+      "stem": false,
+      "stem_dictionary": "",
+      "store": true,
+      "type": "float"
+    },
+    {
+      "embed": {
+        "from": ["title"],
+        "model_config": {
+          "access_token": null,
+          "api_key": "sk-test",
+          "client_id": "",
+          "client_secret": null,
+          "indexing_prefix": "",
+          "model_name": "openai/text-embedding-3-small",
+          "project_id": "",
+          "query_prefix": "",
+          "refresh_token": null,
+          "url": ""
+        }
+      },
+      "facet": false,
+      "index": true,
+      "infix": false,
+      "locale": "",
+      "name": "embedding",
+      "num_dim": 1536,
+      "optional": false,
+      "sort": false,
+      "stem": false,
+      "stem_dictionary": "",
+      "store": true,
+      "type": "float[]"
+    }
+  ],
+  "id": "products",
+  "name": "products",
+  "symbols_to_index": [],
+  "token_separators": ["-"]
+}`
+
+	state := testUpgradeResourceState(t, &CollectionResource{}, 0, rawState)
+
+	var data CollectionResourceModel
+	if diags := state.Get(context.Background(), &data); diags.HasError() {
+		t.Fatalf("unable to read upgraded state: %v", diags)
+	}
+
+	if data.Id.ValueString() != "products" || data.DefaultSortingField.ValueString() != "price" || !data.DeletionProtection.ValueBool() {
+		t.Errorf("expected collection attributes to be kept, got %+v", data)
+	}
+
+	if len(data.TokenSeparators) != 1 || data.TokenSeparators[0].ValueString() != "-" {
+		t.Errorf("expected token separators to be kept, got %v", data.TokenSeparators)
+	}
+
+	names := []string{}
+	for _, field := range data.Fields {
+		names = append(names, field.Name.ValueString())
+	}
+
+	if !slices.Equal(names, []string{"title", "price", "embedding"}) {
+		t.Fatalf("expected fields in stored order, got %v", names)
+	}
+
+	if !data.Fields[1].Facet.ValueBool() || !data.Fields[1].Sort.ValueBool() {
+		t.Errorf("expected price field attributes to be kept, got %+v", data.Fields[1])
+	}
+
+	if embed := data.Fields[2].Embed; embed == nil || embed.ModelConfig == nil || embed.ModelConfig.ApiKey.ValueString() != "sk-test" {
+		t.Errorf("expected embedding model config to be kept, got %+v", embed)
+	}
+
+	if !data.Fields[0].Reference.IsNull() || !data.Fields[0].HnswParams.IsNull() || !data.Metadata.IsNull() {
+		t.Errorf("expected attributes added since version 0 to be null, got %+v", data.Fields[0])
+	}
+
+	if data.FieldChangeStrategy.ValueString() != fieldChangeStrategyInPlace || data.ManagedFields.ValueString() != managedFieldsAll {
+		t.Errorf("expected default strategies, got %s and %s", data.FieldChangeStrategy, data.ManagedFields)
+	}
+}
+
+func TestCollectionResourceUpdate_ReorderedFields(t *testing.T) {
+	altered := false
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/collections/products":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = io.WriteString(w, `{"name": "products", "fields": [{"name": "title", "type": "string"}, {"name": "price", "type": "float", "sort": true}]}`)
+		case r.Method == http.MethodPatch:
+			altered = true
+			w.WriteHeader(http.StatusBadRequest)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `{"message": "Not Found"}`)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	r := &CollectionResource{client: testTypesenseClient(t, server.URL)}
+
+	// The plan diff is by position, every field looks changed after the swap
+	schema, stateValue := testResourceValue(t, r, `{"id": "products", "name": "products", "fields": [
+		{"name": "title", "type": "string"},
+		{"name": "price", "type": "float", "sort": true}
+	]}`)
+	_, planValue := testResourceValue(t, r, `{"id": "products", "name": "products", "fields": [
+		{"name": "price", "type": "float", "sort": true},
+		{"name": "title", "type": "string"}
+	]}`)
+
+	req := fwresource.UpdateRequest{
+		State: tfsdk.State{Schema: schema, Raw: stateValue},
+		Plan:  tfsdk.Plan{Schema: schema, Raw: planValue},
+	}
+	resp := &fwresource.UpdateResponse{State: tfsdk.State{Schema: schema, Raw: stateValue}}
+
+	r.Update(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	if altered {
+		t.Errorf("expected reordered fields not to alter the collection")
+	}
+
+	var data CollectionResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+
+	names := []string{}
+	for _, field := range data.Fields {
+		names = append(names, field.Name.ValueString())
+	}
+
+	if !slices.Equal(names, []string{"price", "title"}) {
+		t.Errorf("expected fields in the planned order, got %v", names)
+	}
+}
+
+func TestUseStateForUnknownFields(t *testing.T) {
+	embedField := func(url types.String) CollectionResourceFieldModel {
+		return CollectionResourceFieldModel{
+			Name:       types.StringValue("embedding"),
+			VecDist:    types.StringValue("cosine"),
+			HnswParams: types.ObjectUnknown(map[string]attr.Type{"m": types.Int64Type, "ef_construction": types.Int64Type}),
+			Embed: &CollectionFieldEmbedModel{
+				ModelConfig: &CollectionFieldEmbedModelConfigModel{
+					ModelName:      types.StringValue("openai/text-embedding-3-small"),
+					Url:            url,
+					ClientId:       types.StringValue(""),
+					IndexingPrefix: types.StringValue(""),
+					ProjectId:      types.StringValue(""),
+					QueryPrefix:    types.StringValue(""),
+				},
+			},
+		}
+	}
+
+	stateField := embedField(types.StringValue("https://api.openai.com"))
+	stateField.HnswParams = types.ObjectNull(map[string]attr.Type{"m": types.Int64Type, "ef_construction": types.Int64Type})
+
+	// The new brand field shifts the embedding field to another index
+	planFields := []CollectionResourceFieldModel{
+		{Name: types.StringValue("brand"), VecDist: types.StringUnknown()},
+		embedField(types.StringUnknown()),
+	}
+
+	if !useStateForUnknownFields(planFields, []CollectionResourceFieldModel{stateField}) {
+		t.Fatal("expected planned fields to be modified")
+	}
+
+	if url := planFields[1].Embed.ModelConfig.Url; url.ValueString() != "https://api.openai.com" {
+		t.Errorf("expected url from the state field with the same name, got %s", url)
+	}
+
+	if !planFields[1].HnswParams.IsNull() {
+		t.Errorf("expected hnsw_params from the state field with the same name, got %s", planFields[1].HnswParams)
+	}
+
+	if !planFields[0].VecDist.IsUnknown() {
+		t.Errorf("expected the new field to stay unknown, got %s", planFields[0].VecDist)
+	}
+}
+
+func TestAccCollectionResource_FieldOrder(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCollectionResourceConfigFieldOrder("test_collection_order", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("typesense_collection.test", "fields.#", "2"),
+					resource.TestCheckResourceAttr("typesense_collection.test", "fields.0.name", "title"),
+					resource.TestCheckResourceAttr("typesense_collection.test", "fields.1.name", "price"),
+				),
+			},
+			// A field added in the middle keeps the configured order
+			{
+				Config: testAccCollectionResourceConfigFieldOrder("test_collection_order", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("typesense_collection.test", "fields.#", "3"),
+					resource.TestCheckResourceAttr("typesense_collection.test", "fields.0.name", "title"),
+					resource.TestCheckResourceAttr("typesense_collection.test", "fields.1.name", "brand"),
+					resource.TestCheckResourceAttr("typesense_collection.test", "fields.2.name", "price"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCollectionResourceConfigFieldOrder(name string, withBrand bool) string {
+	brand := ""
+	if withBrand {
+		brand = `
+  fields {
+    name  = "brand"
+    type  = "string"
+    facet = true
+  }
+`
+	}
+
+	return fmt.Sprintf(`
+resource "typesense_collection" "test" {
+  name = %[1]q
+
+  fields {
+    name = "title"
+    type = "string"
+  }
+%[2]s
+  fields {
+    name = "price"
+    type = "float"
+  }
+}
+`, name, brand)
+}
+
+func TestOrderFieldsLike(t *testing.T) {
+	field := func(name string) CollectionResourceFieldModel {
+		return CollectionResourceFieldModel{Name: types.StringValue(name)}
+	}
+
+	fields := []CollectionResourceFieldModel{field("title"), field("price"), field("auto_added"), field("brand")}
+	reference := []CollectionResourceFieldModel{field("brand"), field("title"), field("price")}
+
+	ordered := orderFieldsLike(fields, reference)
+
+	expected := []string{"brand", "title", "price", "auto_added"}
+	for i, name := range expected {
+		if ordered[i].Name.ValueString() != name {
+			t.Errorf("expected field %d to be %q, got %q", i, name, ordered[i].Name.ValueString())
+		}
+	}
+}
+
+func TestAccCollectionResource_FieldChangeStrategyFail(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCollectionResourceConfigFieldChangeStrategy("test_collection_strategy_fail", "fail", "int32"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("typesense_collection.test", "field_change_strategy", "fail"),
+					resource.TestCheckResourceAttr("typesense_collection.test", "physical_name", "test_collection_strategy_fail"),
+				),
+			},
+			{
+				Config:      testAccCollectionResourceConfigFieldChangeStrategy("test_collection_strategy_fail", "fail", "int64"),
+				ExpectError: regexp.MustCompile(`Field changes not allowed`),
+			},
+		},
+	})
+}
+
+func TestAccCollectionResource_FieldChangeStrategyReindexViaAlias(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCollectionResourceConfigFieldChangeStrategy("test_collection_reindex", "reindex_via_alias", "int32"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("typesense_collection.test", "name", "test_collection_reindex"),
+					resource.TestCheckResourceAttr("typesense_collection.test", "physical_name", "test_collection_reindex_v1"),
+				),
+			},
+			// Changing a field type copies the documents into the next version
+			{
+				Config: testAccCollectionResourceConfigFieldChangeStrategy("test_collection_reindex", "reindex_via_alias", "int64"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("typesense_collection.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("typesense_collection.test", "physical_name", "test_collection_reindex_v2"),
+					resource.TestCheckTypeSetElemNestedAttrs("typesense_collection.test", "fields.*", map[string]string{
+						"name": "price",
+						"type": "int64",
+					}),
+					resource.TestCheckResourceAttr("typesense_document.test", "collection_name", "test_collection_reindex"),
+				),
+			},
+			{
+				Config: testAccCollectionResourceConfigFieldChangeStrategy("test_collection_reindex", "reindex_via_alias", "int64") + `
+data "typesense_search" "test" {
+  collection_name = typesense_collection.test.name
+  q               = "*"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.typesense_search.test", "found", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCollectionResourceConfigFieldChangeStrategy(name string, strategy string, priceType string) string {
+	return fmt.Sprintf(`
+resource "typesense_collection" "test" {
+  name                  = %[1]q
+  field_change_strategy = %[2]q
+
+  fields {
+    name = "title"
+    type = "string"
+  }
+
+  fields {
+    name = "price"
+    type = %[3]q
+  }
+}
+
+resource "typesense_document" "test" {
+  name            = "doc1"
+  collection_name = typesense_collection.test.name
+  document = jsonencode({
+    title = "Shoe"
+    price = 100
+  })
+}
+`, name, strategy, priceType)
+}
+
+func TestAccCollectionResource_ReindexViaAliasSettings(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCollectionResourceConfigReindexSettings("test_collection_bluegreen", "price", "-"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("typesense_collection.test", "physical_name", "test_collection_bluegreen_v1"),
+					resource.TestCheckResourceAttr("typesense_collection.test", "default_sorting_field", "price"),
+				),
+			},
+			// Settings Typesense cannot alter are applied through a new version
+			{
+				Config: testAccCollectionResourceConfigReindexSettings("test_collection_bluegreen", "rating", "/"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("typesense_collection.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("typesense_collection.test", "physical_name", "test_collection_bluegreen_v2"),
+					resource.TestCheckResourceAttr("typesense_collection.test", "default_sorting_field", "rating"),
+					resource.TestCheckResourceAttr("typesense_collection.test", "token_separators.0", "/"),
+				),
+			},
+			// Renaming creates the first version behind the new alias
+			{
+				Config: testAccCollectionResourceConfigReindexSettings("test_collection_bluegreen_renamed", "rating", "/"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("typesense_collection.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("typesense_collection.test", "id", "test_collection_bluegreen_renamed"),
+					resource.TestCheckResourceAttr("typesense_collection.test", "physical_name", "test_collection_bluegreen_renamed_v1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCollectionResourceConfigReindexSettings(name string, defaultSortingField string, tokenSeparator string) string {
+	return fmt.Sprintf(`
+resource "typesense_collection" "test" {
+  name                  = %[1]q
+  field_change_strategy = "reindex_via_alias"
+  default_sorting_field = %[2]q
+  token_separators      = [%[3]q]
+
+  fields {
+    name = "price"
+    type = "int32"
+  }
+
+  fields {
+    name = "rating"
+    type = "int32"
+  }
+}
+`, name, defaultSortingField, tokenSeparator)
+}
+
+func TestAccCollectionResource_DeletionProtectionOnReplace(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCollectionResourceConfigProtectedSorting("test_collection_protected", "price", true),
+			},
+			{
+				Config:      testAccCollectionResourceConfigProtectedSorting("test_collection_protected", "rating", true),
+				ExpectError: regexp.MustCompile(`(?s)Cannot replace protected collection.*default_sorting_field`),
+			},
+			// Turning protection off in the same change is not enough
+			{
+				Config:      testAccCollectionResourceConfigProtectedSorting("test_collection_protected", "rating", false),
+				ExpectError: regexp.MustCompile(`Cannot replace protected collection`),
+			},
+			// Unprotect before destroying
+			{
+				Config: testAccCollectionResourceConfigProtectedSorting("test_collection_protected", "price", false),
+			},
+		},
+	})
+}
+
+func testAccCollectionResourceConfigProtectedSorting(name string, defaultSortingField string, deletionProtection bool) string {
+	return fmt.Sprintf(`
+resource "typesense_collection" "test" {
+  name                  = %[1]q
+  default_sorting_field = %[2]q
+  deletion_protection   = %[3]t
+
+  fields {
+    name = "price"
+    type = "int32"
+  }
+
+  fields {
+    name = "rating"
+    type = "int32"
+  }
+}
+`, name, defaultSortingField, deletionProtection)
+}
+
+func TestCollectionReplacementPaths(t *testing.T) {
+	state := CollectionResourceModel{
+		Name:                types.StringValue("products"),
+		DefaultSortingField: types.StringValue("price"),
+		FieldChangeStrategy: types.StringValue("in_place"),
+		SymbolsToIndex:      []types.String{},
+		TokenSeparators:     []types.String{},
+	}
+
+	plan := state
+	plan.DefaultSortingField = types.StringValue("rating")
+	plan.TokenSeparators = []types.String{types.StringValue("-")}
+
+	paths := collectionReplacementPaths(state, plan)
+	if len(paths) != 2 || paths[0].String() != "default_sorting_field" || paths[1].String() != "token_separators" {
+		t.Errorf("expected default_sorting_field and token_separators, got %v", paths)
+	}
+
+	state.FieldChangeStrategy = types.StringValue("reindex_via_alias")
+	plan.FieldChangeStrategy = types.StringValue("reindex_via_alias")
+
+	if paths := collectionReplacementPaths(state, plan); len(paths) != 0 {
+		t.Errorf("expected no replacement for collections served through an alias, got %v", paths)
+	}
+}
+
+func TestAccCollectionResource_BackupOnDestroy(t *testing.T) {
+	backupPath := filepath.Join(t.TempDir(), "test_collection_backup.jsonl.gz")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			if _, err := os.Stat(backupPath); err != nil {
+				return fmt.Errorf("expected backup file %s, got error: %s", backupPath, err)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCollectionResourceConfigBackupOnDestroy("test_collection_backup", backupPath),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("typesense_collection.test", "backup_on_destroy.path", backupPath),
+					resource.TestCheckResourceAttr("typesense_collection.test", "backup_on_destroy.gzip", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCollectionResourceConfigBackupOnDestroy(name string, backupPath string) string {
+	return fmt.Sprintf(`
+resource "typesense_collection" "test" {
+  name = %[1]q
+
+  backup_on_destroy {
+    path = %[2]q
+    gzip = true
+  }
+
+  fields {
+    name = "title"
+    type = "string"
+  }
+}
+
+resource "typesense_document" "test" {
+  name            = "doc1"
+  collection_name = typesense_collection.test.name
+  document = jsonencode({
+    title = "Shoe"
+  })
+}
+`, name, backupPath)
+}
+
+func TestAccCollectionResource_ManagedFieldsDeclaredOnly(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCollectionResourceConfigManagedFields("test_collection_declared_only", "declared_only"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("typesense_collection.test", "managed_fields", "declared_only"),
+					resource.TestCheckResourceAttr("typesense_collection.test", "fields.#", "2"),
+				),
+			},
+			// The color field auto-created for the document is not reported
+			{
+				Config:   testAccCollectionResourceConfigManagedFields("test_collection_declared_only", "declared_only"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testAccCollectionResourceConfigManagedFields(name string, managedFields string) string {
+	return fmt.Sprintf(`
+resource "typesense_collection" "test" {
+  name           = %[1]q
+  managed_fields = %[2]q
+
+  fields {
+    name = "title"
+    type = "string"
+  }
+
+  fields {
+    name = ".*"
+    type = "auto"
+  }
+}
+
+resource "typesense_document" "test" {
+  name            = "doc1"
+  collection_name = typesense_collection.test.name
+  document = jsonencode({
+    title = "Shoe"
+    color = "red"
+  })
+}
+`, name, managedFields)
+}
+
+func TestFlattenCollection_DeclaredOnly(t *testing.T) {
+	apiField := func(name string) collectionFieldAPI {
+		return collectionFieldAPI{Field: api.Field{Name: name, Type: "string"}}
+	}
+	collection := &collectionResponseAPI{
+		Fields: []collectionFieldAPI{apiField("title"), apiField(".*_facet"), apiField("color_facet"), apiField("dashboard")},
+	}
+
+	data := CollectionResourceModel{
+		ManagedFields: types.StringValue(managedFieldsDeclaredOnly),
+		Fields: []CollectionResourceFieldModel{
+			{Name: types.StringValue("title")},
+			{Name: types.StringValue(".*_facet")},
+		},
+	}
+
+	if diags := flattenCollection(collection, &data); diags.HasError() {
+		t.Fatalf("unable to flatten collection: %v", diags)
+	}
+
+	names := []string{}
+	for _, field := range data.Fields {
+		names = append(names, field.Name.ValueString())
+	}
+
+	// Only the field created for the .*_facet pattern is left out
+	if !slices.Equal(names, []string{"title", ".*_facet", "dashboard"}) {
+		t.Errorf("expected title, .*_facet and dashboard fields, got %v", names)
+	}
+
+	// Imported collections have no declared fields yet, all fields are read
+	imported := CollectionResourceModel{ManagedFields: types.StringValue(managedFieldsDeclaredOnly)}
+
+	if diags := flattenCollection(collection, &imported); diags.HasError() {
+		t.Fatalf("unable to flatten collection: %v", diags)
+	}
+
+	if len(imported.Fields) != 4 {
+		t.Errorf("expected all 4 fields on import, got %d", len(imported.Fields))
+	}
+
+	// With all, the field created for the pattern is read so it gets dropped
+	all := CollectionResourceModel{
+		ManagedFields: types.StringValue(managedFieldsAll),
+		Fields:        data.Fields[:2],
+	}
+
+	if diags := flattenCollection(collection, &all); diags.HasError() {
+		t.Fatalf("unable to flatten collection: %v", diags)
+	}
+
+	names = []string{}
+	for _, field := range all.Fields {
+		names = append(names, field.Name.ValueString())
+	}
+
+	if !slices.Contains(names, "color_facet") {
+		t.Errorf("expected color_facet field with managed_fields all, got %v", names)
+	}
+}
+
+func TestCollectionResourceValidateConfig(t *testing.T) {
+	embedFrom := tftypes.NewAttributePath().WithAttributeName("fields").WithElementKeyInt(1).WithAttributeName("embed").WithAttributeName("from")
+
+	tests := []struct {
+		name        string
+		config      string
+		unknown     []*tftypes.AttributePath
+		expectError string
+	}{
+		{
+			name:        "undeclared default sorting field",
+			config:      `{"fields": [{"name": "title", "type": "string"}], "default_sorting_field": "points"}`,
+			expectError: `Field "points" is not declared in the collection`,
+		},
+		{
+			name:        "undeclared default sorting field of a cloned collection",
+			config:      `{"fields": [{"name": "title", "type": "string"}], "default_sorting_field": "points", "source_collection": "products"}`,
+			expectError: "",
+		},
+		{
+			name:        "string default sorting field",
+			config:      `{"fields": [{"name": "title", "type": "string", "sort": true}], "default_sorting_field": "title"}`,
+			expectError: "must be an int32, int64 or float field",
+		},
+		{
+			name:        "object field without nested fields",
+			config:      `{"fields": [{"name": "address", "type": "object"}]}`,
+			expectError: "requires enable_nested_fields to be true",
+		},
+		{
+			name:        "embedding from a number field",
+			config:      `{"fields": [{"name": "points", "type": "int32"}, {"name": "embedding", "type": "float[]", "embed": {"from": ["points"], "model_config": {"model_name": "ts/all-MiniLM-L12-v2"}}}]}`,
+			expectError: `embeddings can only be generated from string, string\[\] or image fields`,
+		},
+		{
+			name:        "num_dim on a scalar field",
+			config:      `{"fields": [{"name": "embedding", "type": "float", "num_dim": 768}]}`,
+			expectError: `num_dim can only be used on float\[\] fields`,
+		},
+		{
+			name:        "sorted string array",
+			config:      `{"fields": [{"name": "tags", "type": "string[]", "sort": true}]}`,
+			expectError: `string\[\], which cannot be sorted`,
+		},
+		{
+			name:        "embedding model without api key",
+			config:      `{"fields": [{"name": "title", "type": "string"}, {"name": "embedding", "type": "float[]", "embed": {"from": ["title"], "model_config": {"model_name": "openai/text-embedding-3-small"}}}]}`,
+			expectError: "requires api_key to be set",
+		},
+		{
+			name:        "cloned collection reindexed via alias",
+			config:      `{"fields": [{"name": "title", "type": "string"}], "source_collection": "products", "field_change_strategy": "reindex_via_alias"}`,
+			expectError: "cannot use the \"reindex_via_alias\" field change strategy",
+		},
+		{
+			name:    "unknown token separators",
+			config:  `{"fields": [{"name": "title", "type": "string"}], "token_separators": ["-"]}`,
+			unknown: []*tftypes.AttributePath{tftypes.NewAttributePath().WithAttributeName("token_separators")},
+		},
+		{
+			name:    "unknown embedding source",
+			config:  `{"fields": [{"name": "title", "type": "string"}, {"name": "embedding", "type": "float[]", "embed": {"from": ["title"], "model_config": {"model_name": "ts/all-MiniLM-L12-v2"}}}]}`,
+			unknown: []*tftypes.AttributePath{embedFrom},
+		},
+		{
+			name:    "unknown field type",
+			config:  `{"fields": [{"name": "points", "type": "int32"}], "default_sorting_field": "points"}`,
+			unknown: []*tftypes.AttributePath{tftypes.NewAttributePath().WithAttributeName("fields").WithElementKeyInt(0).WithAttributeName("type")},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resp := &fwresource.ValidateConfigResponse{}
+			(&CollectionResource{}).ValidateConfig(context.Background(), fwresource.ValidateConfigRequest{
+				Config: testCollectionConfig(t, test.config, test.unknown...),
+			}, resp)
+
+			if test.expectError == "" {
+				if resp.Diagnostics.HasError() {
+					t.Fatalf("unexpected error: %v", resp.Diagnostics)
+				}
+				return
+			}
+
+			if !resp.Diagnostics.HasError() {
+				t.Fatalf("expected error matching %q, got none", test.expectError)
+			}
+
+			errors := resp.Diagnostics.Errors()
+			if !regexp.MustCompile(test.expectError).MatchString(errors[0].Detail()) {
+				t.Errorf("expected error matching %q, got %v", test.expectError, errors)
+			}
+		})
+	}
+}
+
+// testCollectionConfig decodes a JSON collection configuration with
+// testResourceValue.
+func testCollectionConfig(t *testing.T, rawConfig string, unknown ...*tftypes.AttributePath) tfsdk.Config {
+	t.Helper()
+
+	configSchema, value := testResourceValue(t, &CollectionResource{}, rawConfig, unknown...)
+
+	return tfsdk.Config{Schema: configSchema, Raw: value}
+}
+
+func testAccCollectionResourceConfigValidate(body string) string {
+	return fmt.Sprintf(`
+resource "typesense_collection" "test" {
+  name = "test_collection_validate"
+%s}
+`, body)
+}
+
+func TestAccCollectionResource_NumDocuments(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCollectionResourceConfigNumDocuments("test_collection_num_documents", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("typesense_collection.test", "num_documents", "0"),
+					resource.TestCheckResourceAttrSet("typesense_collection.test", "created_at"),
+				),
+			},
+			{
+				Config: testAccCollectionResourceConfigNumDocuments("test_collection_num_documents", true),
+			},
+			// The document is only counted once the collection is refreshed
+			{
+				Config: testAccCollectionResourceConfigNumDocuments("test_collection_num_documents", true),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("typesense_collection.test", "num_documents", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCollectionResourceConfigNumDocuments(name string, withDocument bool) string {
+	config := fmt.Sprintf(`
+resource "typesense_collection" "test" {
+  name = %[1]q
+
+  fields {
+    name = "title"
+    type = "string"
+  }
+}
+`, name)
+
+	if withDocument {
+		config += `
+resource "typesense_document" "test" {
+  name            = "1"
+  collection_name = typesense_collection.test.name
+  document = jsonencode({
+    title = "Seeded"
+  })
+}
+`
+	}
+
+	return config
+}
+
+func TestAccCollectionResource_TruncateOn(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCollectionResourceConfigTruncateOn("test_collection_truncate", "1"),
+			},
+			{
+				Config: testAccCollectionResourceConfigTruncateOn("test_collection_truncate", "1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("typesense_collection.test", "num_documents", "1"),
+				),
+			},
+			// The seeded document is gone, so the document resource plans a create
+			{
+				Config: testAccCollectionResourceConfigTruncateOn("test_collection_truncate", "2"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("typesense_collection.test", plancheck.ResourceActionUpdate),
+						plancheck.ExpectUnknownValue("typesense_collection.test", tfjsonpath.New("num_documents")),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("typesense_collection.test", "num_documents", "0"),
+					resource.TestCheckResourceAttr("typesense_collection.test", "fields.#", "1"),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccCollectionResourceConfigTruncateOn(name string, seed string) string {
+	return fmt.Sprintf(`
+resource "typesense_collection" "test" {
+  name = %[1]q
+
+  truncate_on = {
+    seed = %[2]q
+  }
+
+  fields {
+    name = "title"
+    type = "string"
+  }
+}
+
+resource "typesense_document" "test" {
+  name            = "1"
+  collection_name = typesense_collection.test.name
+  document = jsonencode({
+    title = "Seeded"
+  })
+}
+`, name, seed)
+}
+
+func TestTruncateTriggered(t *testing.T) {
+	seed := func(value string) types.Map {
+		return types.MapValueMust(types.StringType, map[string]attr.Value{"seed": types.StringValue(value)})
+	}
+
+	tests := []struct {
+		name     string
+		state    types.Map
+		plan     types.Map
+		expected bool
+	}{
+		{"unset", types.MapNull(types.StringType), types.MapNull(types.StringType), false},
+		{"unchanged", seed("1"), seed("1"), false},
+		{"changed", seed("1"), seed("2"), true},
+		{"removed", seed("1"), types.MapNull(types.StringType), false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			state := CollectionResourceModel{TruncateOn: test.state}
+			plan := CollectionResourceModel{TruncateOn: test.plan}
+
+			if got := truncateTriggered(state, plan); got != test.expected {
+				t.Errorf("expected %t, got %t", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestAccCollectionResource_Timeouts(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCollectionResourceConfigTimeouts("test_collection_timeouts", "1ns"),
+				ExpectError: regexp.MustCompile(`context deadline exceeded`),
+			},
+			{
+				Config: testAccCollectionResourceConfigTimeouts("test_collection_timeouts", "10m"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("typesense_collection.test", "timeouts.create", "10m"),
+					resource.TestCheckResourceAttr("typesense_collection.test", "timeouts.update", "30m"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCollectionResourceConfigTimeouts(name string, create string) string {
+	return fmt.Sprintf(`
+resource "typesense_collection" "test" {
+  name = %[1]q
+
+  fields {
+    name = "title"
+    type = "string"
+  }
+
+  timeouts {
+    create = %[2]q
+    update = "30m"
+  }
+}
+`, name, create)
+}
+
+func TestCollectionFieldsDrift(t *testing.T) {
+	field := func(name string, fieldType string, facet bool) CollectionResourceFieldModel {
+		return CollectionResourceFieldModel{
+			Name:  types.StringValue(name),
+			Type:  types.StringValue(fieldType),
+			Facet: types.BoolValue(facet),
+			Stem:  types.BoolNull(),
+		}
+	}
+
+	prior := []CollectionResourceFieldModel{
+		field("title", "string", false),
+		field("brand", "string", false),
+		field("price", "float", false),
+	}
+
+	// stem was not tracked in the prior state, reading it is not drift
+	title := field("title", "string", false)
+	title.Stem = types.BoolValue(false)
+
+	current := []CollectionResourceFieldModel{
+		title,
+		field("brand", "string", true),
+		field("color", "string", false),
+	}
+
+	drift := collectionFieldsDrift(prior, current)
+	expected := []string{
+		`field "brand" was modified (facet)`,
+		`field "color" was added`,
+		`field "price" was removed`,
+	}
+
+	if !slices.Equal(drift, expected) {
+		t.Errorf("expected drift %v, got %v", expected, drift)
+	}
+
+	if drift := collectionFieldsDrift(current, current); len(drift) != 0 {
+		t.Errorf("expected no drift, got %v", drift)
+	}
+}
+
+func TestValidateEmbedModelConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   CollectionFieldEmbedModelConfigModel
+		expected []string
+	}{
+		{
+			name:     "built-in model",
+			config:   CollectionFieldEmbedModelConfigModel{ModelName: types.StringValue("ts/all-MiniLM-L12-v2")},
+			expected: []string{},
+		},
+		{
+			name:     "unknown provider",
+			config:   CollectionFieldEmbedModelConfigModel{ModelName: types.StringValue("cohere/embed-english-v3.0")},
+			expected: []string{"model_name"},
+		},
+		{
+			name:     "missing model name",
+			config:   CollectionFieldEmbedModelConfigModel{ModelName: types.StringNull()},
+			expected: []string{"model_name"},
+		},
+		{
+			name: "openai with api key",
+			config: CollectionFieldEmbedModelConfigModel{
+				ModelName: types.StringValue("openai/text-embedding-3-small"),
+				ApiKey:    types.StringUnknown(),
+			},
+			expected: []string{},
+		},
+		{
+			name: "azure without url",
+			config: CollectionFieldEmbedModelConfigModel{
+				ModelName: types.StringValue("azure/embeddings"),
+				ApiKey:    types.StringValue("secret"),
+			},
+			expected: []string{"url"},
+		},
+		{
+			name: "gcp without oauth",
+			config: CollectionFieldEmbedModelConfigModel{
+				ModelName: types.StringValue("gcp/embedding-gecko-001"),
+				ProjectId: types.StringValue("my-project"),
+			},
+			expected: []string{"access_token", "refresh_token", "client_id", "client_secret"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			configPath := path.Root("model_config")
+
+			validateEmbedModelConfig(&test.config, configPath, &diags)
+
+			attributes := []string{}
+			for _, d := range diags {
+				if withPath, ok := d.(diag.DiagnosticWithPath); ok {
+					attributes = append(attributes, strings.TrimPrefix(withPath.Path().String(), "model_config."))
+				}
+			}
+
+			if !slices.Equal(attributes, test.expected) {
+				t.Errorf("expected errors on %v, got %v", test.expected, attributes)
+			}
+		})
+	}
+}
+
+func TestKeepEmbedSecrets(t *testing.T) {
+	embedField := func(apiKey types.String) CollectionResourceFieldModel {
+		return CollectionResourceFieldModel{
+			Name: types.StringValue("embedding"),
+			Embed: &CollectionFieldEmbedModel{
+				ModelConfig: &CollectionFieldEmbedModelConfigModel{
+					ModelName:    types.StringValue("openai/text-embedding-3-small"),
+					ApiKey:       apiKey,
+					AccessToken:  types.StringNull(),
+					ClientSecret: types.StringNull(),
+					RefreshToken: types.StringNull(),
+				},
+			},
+		}
+	}
+
+	read := []CollectionResourceFieldModel{embedField(types.StringValue("sk-********"))}
+	kept := keepEmbedSecrets(read, []CollectionResourceFieldModel{embedField(types.StringValue("sk-secret"))})
+
+	if apiKey := kept[0].Embed.ModelConfig.ApiKey.ValueString(); apiKey != "sk-secret" {
+		t.Errorf("expected api_key to be kept from the reference, got %q", apiKey)
+	}
+
+	read = []CollectionResourceFieldModel{embedField(types.StringValue("sk-********"))}
+	kept = keepEmbedSecrets(read, nil)
+
+	if !kept[0].Embed.ModelConfig.ApiKey.IsNull() {
+		t.Errorf("expected api_key not to be read back, got %q", kept[0].Embed.ModelConfig.ApiKey.ValueString())
+	}
+}
+
+func TestEmbedSecretsChange(t *testing.T) {
+	embedField := func(apiKey types.String) CollectionResourceFieldModel {
+		return CollectionResourceFieldModel{
+			Name: types.StringValue("embedding"),
+			Type: types.StringValue("float[]"),
+			Embed: &CollectionFieldEmbedModel{
+				ModelConfig: &CollectionFieldEmbedModelConfigModel{
+					ModelName:    types.StringValue("openai/text-embedding-3-small"),
+					ApiKey:       apiKey,
+					AccessToken:  types.StringNull(),
+					ClientSecret: types.StringNull(),
+					RefreshToken: types.StringNull(),
+				},
+			},
+		}
+	}
+
+	tests := map[string]struct {
+		prior    types.String
+		planned  types.String
+		expected bool
+	}{
+		"unchanged": {prior: types.StringValue("sk-old"), planned: types.StringValue("sk-old")},
+		"rotated":   {prior: types.StringValue("sk-old"), planned: types.StringValue("sk-new"), expected: true},
+		"imported":  {prior: types.StringNull(), planned: types.StringValue("sk-old"), expected: true},
+		"removed":   {prior: types.StringValue("sk-old"), planned: types.StringNull()},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			prior, planned := embedField(test.prior), embedField(test.planned)
+
+			// Secrets never drop the field and embed the documents again
+			if !fieldsEqual(prior, planned) {
+				t.Errorf("expected fields differing only by secrets to be equal")
+			}
+
+			if changed := changedCollectionFields([]CollectionResourceFieldModel{prior}, []CollectionResourceFieldModel{planned}); len(changed) != 0 {
+				t.Errorf("expected no changed fields, got %v", changed)
+			}
+
+			if changed := embedSecretsChanged(prior, planned); changed != test.expected {
+				t.Errorf("expected secrets changed %t, got %t", test.expected, changed)
+			}
+		})
+	}
+
+	// The prior field is not modified by the comparison
+	prior := embedField(types.StringValue("sk-old"))
+	fieldsEqual(prior, embedField(types.StringValue("sk-new")))
+
+	if prior.Embed.ModelConfig.ApiKey.ValueString() != "sk-old" {
+		t.Errorf("expected the compared field to keep its api_key, got %q", prior.Embed.ModelConfig.ApiKey.ValueString())
+	}
+}
+
+func TestFlattenCollectionMetadata(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata map[string]interface{}
+		expected jsontypes.Normalized
+	}{
+		{"missing", nil, jsontypes.NewNormalizedNull()},
+		{"empty", map[string]interface{}{}, jsontypes.NewNormalizedValue("{}")},
+		{"set", map[string]interface{}{"owner": "search"}, jsontypes.NewNormalizedValue(`{"owner":"search"}`)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			metadata, err := flattenCollectionMetadata(test.metadata)
+			if err != nil {
+				t.Fatalf("unable to flatten metadata: %s", err)
+			}
+
+			if equal, _ := metadata.StringSemanticEquals(context.Background(), test.expected); !equal && !metadata.Equal(test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, metadata)
+			}
+		})
+	}
+}
+
+func TestFlattenCollection_EmptyMetadata(t *testing.T) {
+	data := CollectionResourceModel{Metadata: jsontypes.NewNormalizedValue("{ }")}
+
+	if diags := flattenCollection(&collectionResponseAPI{}, &data); diags.HasError() {
+		t.Fatalf("unable to flatten collection: %v", diags)
+	}
+
+	if data.Metadata.ValueString() != "{}" {
+		t.Errorf("expected configured empty metadata to be kept, got %s", data.Metadata)
+	}
+
+	// Removing the metadata from the configuration sends {}, which Typesense
+	// may return as is
+	data = CollectionResourceModel{Metadata: jsontypes.NewNormalizedNull()}
+
+	if diags := flattenCollection(&collectionResponseAPI{Metadata: map[string]interface{}{}}, &data); diags.HasError() {
+		t.Fatalf("unable to flatten collection: %v", diags)
+	}
+
+	if !data.Metadata.IsNull() {
+		t.Errorf("expected removed metadata to stay null, got %s", data.Metadata)
+	}
+}
+
+func TestAccCollectionResource_EmptyMetadata(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCollectionResourceConfigValidate(`
+  fields {
+    name = "title"
+    type = "string"
+  }
+
+  metadata = jsonencode({})
+`),
+				Check: resource.TestCheckResourceAttr("typesense_collection.test", "metadata", "{}"),
+			},
+			{
+				Config: testAccCollectionResourceConfigValidate(`
+  fields {
+    name = "title"
+    type = "string"
+  }
+`),
+				Check: resource.TestCheckNoResourceAttr("typesense_collection.test", "metadata"),
+			},
+		},
+	})
+}
+
+func TestAlterCollection_SchemaChangeInProgress(t *testing.T) {
+	updated := false
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/operations/schema_changes":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = io.WriteString(w, `[{"collection": "products", "validated_docs": 100, "altered_docs": 50}]`)
+		case r.Method == http.MethodPatch && r.URL.Path == "/collections/products":
+			updated = true
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = io.WriteString(w, `{}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	r := &CollectionResource{client: testTypesenseClient(t, server.URL)}
+
+	var diags diag.Diagnostics
+	altered := r.alterCollection(context.Background(), "products", &collectionUpdateSchemaAPI{Fields: []collectionFieldAPI{{Field: api.Field{Name: "note", Type: "string"}}}}, &diags)
+
+	if altered || !diags.HasError() || diags.Errors()[0].Summary() != "Schema change in progress" {
+		t.Errorf("expected the concurrent alteration to be rejected, got %v", diags)
+	}
+
+	if updated {
+		t.Errorf("expected the collection not to be updated")
+	}
+}
+
+func TestAlterCollection_ReportsProgress(t *testing.T) {
+	pollInterval := schemaChangePollInterval
+	schemaChangePollInterval = time.Millisecond
+	t.Cleanup(func() { schemaChangePollInterval = pollInterval })
+
+	var mu sync.Mutex
+	altering := false
+	pollsDuringAlter := 0
+	polledDuringAlter := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/operations/schema_changes":
+			mu.Lock()
+			defer mu.Unlock()
+
+			if !altering {
+				_, _ = io.WriteString(w, `[]`)
+				return
+			}
+
+			pollsDuringAlter++
+			if pollsDuringAlter == 2 {
+				close(polledDuringAlter)
+			}
+			_, _ = io.WriteString(w, `[{"collection": "products", "validated_docs": 100, "altered_docs": 50}]`)
+		case r.Method == http.MethodPatch && r.URL.Path == "/collections/products":
+			mu.Lock()
+			altering = true
+			mu.Unlock()
+
+			// Typesense only answers once the documents are altered
+			select {
+			case <-polledDuringAlter:
+			case <-time.After(5 * time.Second):
+			}
+
+			mu.Lock()
+			altering = false
+			mu.Unlock()
+
+			_, _ = io.WriteString(w, `{}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	r := &CollectionResource{client: testTypesenseClient(t, server.URL)}
+
+	var diags diag.Diagnostics
+	altered := r.alterCollection(context.Background(), "products", &collectionUpdateSchemaAPI{Fields: []collectionFieldAPI{{Field: api.Field{Name: "note", Type: "string"}}}}, &diags)
+
+	if !altered || diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if pollsDuringAlter < 2 {
+		t.Errorf("expected the alteration to be polled while the request is in flight, got %d polls", pollsDuringAlter)
+	}
+}
@@ -4,8 +4,11 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -21,6 +24,19 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &DocumentResource{}
 var _ resource.ResourceWithImportState = &DocumentResource{}
+var _ resource.ResourceWithUpgradeState = &DocumentResource{}
+
+// defaultDocumentTimeout bounds document operations without a configured
+// timeout.
+const defaultDocumentTimeout = 5 * time.Minute
+
+// timeoutsAttrTypes are the attribute types of the timeouts block.
+var timeoutsAttrTypes = map[string]attr.Type{
+	"create": types.StringType,
+	"read":   types.StringType,
+	"update": types.StringType,
+	"delete": types.StringType,
+}
 
 func NewDocumentResource() resource.Resource {
 	return &DocumentResource{}
@@ -35,6 +51,15 @@ type DocumentResourceModel struct {
 	Name           types.String         `tfsdk:"name"`
 	CollectionName types.String         `tfsdk:"collection_name"`
 	Document       jsontypes.Normalized `tfsdk:"document"`
+	Timeouts       timeouts.Value       `tfsdk:"timeouts"`
+}
+
+// documentResourceModelV0 is the state of schema version 0.
+type documentResourceModelV0 struct {
+	Id             types.String         `tfsdk:"id"`
+	Name           types.String         `tfsdk:"name"`
+	CollectionName types.String         `tfsdk:"collection_name"`
+	Document       jsontypes.Normalized `tfsdk:"document"`
 }
 
 func (r *DocumentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -43,6 +68,7 @@ func (r *DocumentResource) Metadata(ctx context.Context, req resource.MetadataRe
 
 func (r *DocumentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:             1,
 		MarkdownDescription: "Every record you index in Typesense is called a Document",
 
 		Attributes: map[string]schema.Attribute{
@@ -73,6 +99,14 @@ func (r *DocumentResource) Schema(ctx context.Context, req resource.SchemaReques
 				CustomType:          jsontypes.NormalizedType{},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -82,7 +116,7 @@ func (r *DocumentResource) Configure(ctx context.Context, req resource.Configure
 		return
 	}
 
-	client, ok := req.ProviderData.(*typesense.Client)
+	client, ok := req.ProviderData.(*typesenseClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
@@ -93,7 +127,7 @@ func (r *DocumentResource) Configure(ctx context.Context, req resource.Configure
 		return
 	}
 
-	r.client = client
+	r.client = client.Client
 }
 
 func (r *DocumentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -106,6 +140,16 @@ func (r *DocumentResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultDocumentTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	document, err := parseJsonStringToMap(data.Document.ValueString())
 
 	if err != nil {
@@ -163,6 +207,16 @@ func (r *DocumentResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultDocumentTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	collectionName, id, parseError := splitCollectionRelatedId(data.Id.ValueString())
 	if parseError != nil {
 		resp.Diagnostics.AddError("Invalid ID", fmt.Sprintf("Unable to split resource ID: %s", parseError))
@@ -212,6 +266,16 @@ func (r *DocumentResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultDocumentTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	document, err := parseJsonStringToMap(data.Document.ValueString())
 
 	if err != nil {
@@ -255,6 +319,16 @@ func (r *DocumentResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDocumentTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	collectionName, id, parseError := splitCollectionRelatedId(data.Id.ValueString())
 	if parseError != nil {
 		resp.Diagnostics.AddError("Invalid ID", fmt.Sprintf("Unable to split resource ID: %s", parseError))
@@ -279,6 +353,41 @@ func (r *DocumentResource) Delete(ctx context.Context, req resource.DeleteReques
 	data.Id = types.StringValue("")
 }
 
+func (r *DocumentResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 0 had no timeouts block.
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"id":              schema.StringAttribute{Computed: true},
+					"name":            schema.StringAttribute{Required: true},
+					"collection_name": schema.StringAttribute{Required: true},
+					"document":        schema.StringAttribute{Required: true, CustomType: jsontypes.NormalizedType{}},
+				},
+			},
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior documentResourceModelV0
+
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				data := DocumentResourceModel{
+					Id:             prior.Id,
+					Name:           prior.Name,
+					CollectionName: prior.CollectionName,
+					Document:       prior.Document,
+					Timeouts:       timeouts.Value{Object: types.ObjectNull(timeoutsAttrTypes)},
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			},
+		},
+	}
+}
+
 func (r *DocumentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	// ID format is: collection_name.document_id
 	collectionName, documentId, err := splitCollectionRelatedId(req.ID)
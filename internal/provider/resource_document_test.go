@@ -1,7 +1,9 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -270,3 +272,72 @@ resource "typesense_document" "test" {
 }
 `, collectionName, docName)
 }
+
+func TestAccDocumentResource_Timeouts(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccDocumentResourceConfigTimeouts("test_collection_doc_timeouts", "1ns"),
+				ExpectError: regexp.MustCompile(`context deadline exceeded`),
+			},
+			{
+				Config: testAccDocumentResourceConfigTimeouts("test_collection_doc_timeouts", "2m"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("typesense_document.test", "timeouts.create", "2m"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDocumentResourceConfigTimeouts(collectionName string, create string) string {
+	return fmt.Sprintf(`
+resource "typesense_collection" "test" {
+  name = %[1]q
+
+  fields {
+    name = "title"
+    type = "string"
+  }
+}
+
+resource "typesense_document" "test" {
+  name            = "1"
+  collection_name = typesense_collection.test.name
+  document = jsonencode({
+    title = "Timeouts"
+  })
+
+  timeouts {
+    create = %[2]q
+  }
+}
+`, collectionName, create)
+}
+
+func TestDocumentResource_UpgradeStateV0(t *testing.T) {
+	// State written by the provider before schema versions were introduced
+	state := testUpgradeResourceState(t, &DocumentResource{}, 0, `{
+  "collection_name": "products",
+  "document": "{\"price\":120,\"title\":\"Shoe\"}",
+  "id": "products.sku-1",
+  "name": "sku-1"
+}`)
+
+	var data DocumentResourceModel
+	if diags := state.Get(context.Background(), &data); diags.HasError() {
+		t.Fatalf("unable to read upgraded state: %v", diags)
+	}
+
+	if data.Id.ValueString() != "products.sku-1" || data.Name.ValueString() != "sku-1" || data.CollectionName.ValueString() != "products" {
+		t.Errorf("expected document identity to be kept, got %+v", data)
+	}
+	if data.Document.ValueString() != `{"price":120,"title":"Shoe"}` {
+		t.Errorf("expected document to be kept, got %q", data.Document.ValueString())
+	}
+	if !data.Timeouts.IsNull() {
+		t.Errorf("expected no timeouts, got %v", data.Timeouts)
+	}
+}
@@ -0,0 +1,288 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ScopedKeyResource{}
+var _ resource.ResourceWithModifyPlan = &ScopedKeyResource{}
+
+func NewScopedKeyResource() resource.Resource {
+	return &ScopedKeyResource{}
+}
+
+// ScopedKeyResource derives a scoped search key locally, it never talks to the
+// Typesense server.
+type ScopedKeyResource struct{}
+
+type ScopedKeyResourceModel struct {
+	Id                 types.String `tfsdk:"id"`
+	ParentKey          types.String `tfsdk:"parent_key"`
+	FilterBy           types.String `tfsdk:"filter_by"`
+	ExpiresAt          types.Int64  `tfsdk:"expires_at"`
+	Validity           types.String `tfsdk:"validity"`
+	LimitMultiSearches types.Int64  `tfsdk:"limit_multi_searches"`
+	RotateBefore       types.String `tfsdk:"rotate_before"`
+	GeneratedAt        types.String `tfsdk:"generated_at"`
+	Value              types.String `tfsdk:"value"`
+}
+
+func (r *ScopedKeyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_scoped_key"
+}
+
+func (r *ScopedKeyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Scoped search key derived from a search-only API key with embedded search parameters. The key is generated locally and, with `validity`, regenerated with a new expiry once `rotate_before` is reached.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Id identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"parent_key": schema.StringAttribute{
+				MarkdownDescription: "Search-only API key the scoped key is derived from, e.g. `typesense_api_key.search.value`",
+				Required:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"filter_by": schema.StringAttribute{
+				MarkdownDescription: "Filter embedded in the key, it is applied to every search made with it",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"expires_at": schema.Int64Attribute{
+				MarkdownDescription: "Unix timestamp after which the scoped key is rejected. Must not exceed the expiry of the parent key. Computed from `validity` when it is set.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"validity": schema.StringAttribute{
+				MarkdownDescription: "How long the key is valid from the time it is generated, as a duration like `720h`. Sets `expires_at`, so every rotation generates a key with a new expiry.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("expires_at")),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"limit_multi_searches": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of searches allowed in a single multi search request",
+				Optional:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"rotate_before": schema.StringAttribute{
+				MarkdownDescription: "RFC3339 timestamp, the key is marked for replacement on the first plan after this time. Requires `validity`, as a key regenerated with the same expiry is the same key.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("validity")),
+				},
+			},
+			"generated_at": schema.StringAttribute{
+				MarkdownDescription: "RFC3339 timestamp of when the key was generated",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"value": schema.StringAttribute{
+				MarkdownDescription: "The scoped search key",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ScopedKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ScopedKeyResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	generatedAt := time.Now().UTC()
+
+	if !data.Validity.IsNull() {
+		validity, err := time.ParseDuration(data.Validity.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("validity"), "Invalid validity", fmt.Sprintf("validity must be a duration, got error: %s", err))
+			return
+		}
+		data.ExpiresAt = types.Int64Value(generatedAt.Add(validity).Unix())
+	} else if data.ExpiresAt.IsUnknown() {
+		data.ExpiresAt = types.Int64Null()
+	}
+
+	params := map[string]interface{}{}
+
+	if !data.FilterBy.IsNull() {
+		params["filter_by"] = data.FilterBy.ValueString()
+	}
+
+	if !data.ExpiresAt.IsNull() {
+		params["expires_at"] = data.ExpiresAt.ValueInt64()
+	}
+
+	if !data.LimitMultiSearches.IsNull() {
+		params["limit_multi_searches"] = data.LimitMultiSearches.ValueInt64()
+	}
+
+	paramsJson, err := json.Marshal(params)
+	if err != nil {
+		resp.Diagnostics.AddError("JSON format error", fmt.Sprintf("Unable to encode search parameters, got error: %s", err))
+		return
+	}
+
+	value, err := generateScopedSearchKey(data.ParentKey.ValueString(), string(paramsJson))
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("parent_key"), "Unable to generate scoped key", err.Error())
+		return
+	}
+
+	hash := sha256.Sum256([]byte(value))
+
+	data.Id = types.StringValue(hex.EncodeToString(hash[:])[:16])
+	data.GeneratedAt = types.StringValue(generatedAt.Format(time.RFC3339))
+	data.Value = types.StringValue(value)
+
+	tflog.Info(ctx, "Generated scoped key with ID: "+data.Id.ValueString())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ScopedKeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ScopedKeyResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Scoped keys only exist in Terraform state, there is nothing to refresh.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ScopedKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ScopedKeyResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only rotate_before can change in-place, every embedded parameter
+	// requires a new key.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ScopedKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Scoped keys cannot be revoked on their own, they stop working once
+	// they expire or once the parent key is deleted.
+}
+
+func (r *ScopedKeyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan ScopedKeyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.Validity.IsNull() && !plan.Validity.IsUnknown() {
+		if validity, err := time.ParseDuration(plan.Validity.ValueString()); err != nil || validity <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("validity"),
+				"Invalid validity",
+				fmt.Sprintf("validity must be a positive duration like 720h, got %q.", plan.Validity.ValueString()),
+			)
+			return
+		}
+	}
+
+	if plan.RotateBefore.IsNull() || plan.RotateBefore.IsUnknown() {
+		return
+	}
+
+	rotateBefore, err := time.Parse(time.RFC3339, plan.RotateBefore.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("rotate_before"),
+			"Invalid rotate_before",
+			fmt.Sprintf("rotate_before must be an RFC3339 timestamp, got error: %s", err),
+		)
+		return
+	}
+
+	// Nothing to rotate on create
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var state ScopedKeyResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	generatedAt, err := time.Parse(time.RFC3339, state.GeneratedAt.ValueString())
+	if err != nil {
+		return
+	}
+
+	// Keys generated after rotate_before were already rotated, this avoids
+	// a replacement on every plan until rotate_before is moved.
+	if time.Now().After(rotateBefore) && generatedAt.Before(rotateBefore) {
+		tflog.Info(ctx, "Scoped key reached rotate_before, it will be regenerated")
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("rotate_before"))
+
+		// The new key gets a new expiry from validity
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("expires_at"), types.Int64Unknown())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("generated_at"), types.StringUnknown())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("value"), types.StringUnknown())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+	}
+}
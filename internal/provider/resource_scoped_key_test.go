@@ -0,0 +1,141 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccScopedKeyResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccScopedKeyResourceConfig("company_id:124", "2000-01-01T00:00:00Z"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("typesense_scoped_key.test", "value"),
+					resource.TestCheckResourceAttrSet("typesense_scoped_key.test", "expires_at"),
+					resource.TestCheckResourceAttr("typesense_scoped_key.test", "filter_by", "company_id:124"),
+					resource.TestCheckResourceAttrSet("typesense_scoped_key.test", "id"),
+					resource.TestCheckResourceAttrSet("typesense_scoped_key.test", "generated_at"),
+				),
+			},
+			// A key generated after rotate_before must not be rotated again
+			{
+				Config:   testAccScopedKeyResourceConfig("company_id:124", "2000-01-01T00:00:00Z"),
+				PlanOnly: true,
+			},
+			// Changing embedded parameters generates a new key
+			{
+				Config: testAccScopedKeyResourceConfig("company_id:125", "2000-01-01T00:00:00Z"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("typesense_scoped_key.test", "filter_by", "company_id:125"),
+					resource.TestCheckResourceAttrSet("typesense_scoped_key.test", "value"),
+				),
+			},
+		},
+	})
+}
+
+func testAccScopedKeyResourceConfig(filterBy string, rotateBefore string) string {
+	return fmt.Sprintf(`
+resource "typesense_scoped_key" "test" {
+  parent_key    = %[1]q
+  filter_by     = %[2]q
+  validity      = "720h"
+  rotate_before = %[3]q
+}
+`, testScopedSearchParentKey, filterBy, rotateBefore)
+}
+
+func TestScopedKeyResourceModifyPlan(t *testing.T) {
+	const rawState = `{"id": "1234", "parent_key": "key", "validity": "720h", "expires_at": 946684800, "rotate_before": "2000-01-01T00:00:00Z", "generated_at": %q, "value": "scoped"}`
+
+	tests := []struct {
+		name          string
+		generatedAt   string
+		validity      string
+		expectReplace bool
+		expectError   bool
+	}{
+		{name: "generated before rotate_before", generatedAt: "1999-12-01T00:00:00Z", validity: "720h", expectReplace: true},
+		{name: "generated after rotate_before", generatedAt: "2000-01-02T00:00:00Z", validity: "720h"},
+		{name: "invalid validity", generatedAt: "1999-12-01T00:00:00Z", validity: "-1h", expectError: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			r := &ScopedKeyResource{}
+
+			stateSchema, stateValue := testResourceValue(t, r, fmt.Sprintf(rawState, test.generatedAt))
+			_, planValue := testResourceValue(t, r, fmt.Sprintf(`{"id": "1234", "parent_key": "key", "validity": %q, "expires_at": 946684800, "rotate_before": "2000-01-01T00:00:00Z", "generated_at": %q, "value": "scoped"}`, test.validity, test.generatedAt))
+
+			req := fwresource.ModifyPlanRequest{
+				State: tfsdk.State{Schema: stateSchema, Raw: stateValue},
+				Plan:  tfsdk.Plan{Schema: stateSchema, Raw: planValue},
+			}
+			resp := &fwresource.ModifyPlanResponse{Plan: req.Plan}
+
+			r.ModifyPlan(ctx, req, resp)
+
+			if resp.Diagnostics.HasError() != test.expectError {
+				t.Fatalf("expected error %t, got %v", test.expectError, resp.Diagnostics)
+			}
+
+			replace := len(resp.RequiresReplace) > 0
+			if replace != test.expectReplace {
+				t.Fatalf("expected replace %t, got %v", test.expectReplace, resp.RequiresReplace)
+			}
+
+			var expiresAt types.Int64
+			resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("expires_at"), &expiresAt)...)
+
+			if expiresAt.IsUnknown() != test.expectReplace {
+				t.Errorf("expected a new expires_at only on rotation, got %s", expiresAt)
+			}
+		})
+	}
+}
+
+func TestScopedKeyResourceCreate_Validity(t *testing.T) {
+	ctx := context.Background()
+	r := &ScopedKeyResource{}
+
+	unknown := []*tftypes.AttributePath{
+		tftypes.NewAttributePath().WithAttributeName("id"),
+		tftypes.NewAttributePath().WithAttributeName("expires_at"),
+		tftypes.NewAttributePath().WithAttributeName("generated_at"),
+		tftypes.NewAttributePath().WithAttributeName("value"),
+	}
+	planSchema, planValue := testResourceValue(t, r, fmt.Sprintf(`{"parent_key": %q, "validity": "1h"}`, testScopedSearchParentKey), unknown...)
+
+	resp := &fwresource.CreateResponse{
+		State: tfsdk.State{Schema: planSchema, Raw: tftypes.NewValue(planValue.Type(), nil)},
+	}
+
+	before := time.Now()
+	r.Create(ctx, fwresource.CreateRequest{Plan: tfsdk.Plan{Schema: planSchema, Raw: planValue}}, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var data ScopedKeyResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+
+	expiresAt := data.ExpiresAt.ValueInt64()
+	if expiresAt < before.Add(time.Hour).Unix() || expiresAt > time.Now().Add(time.Hour).Unix() {
+		t.Errorf("expected expires_at one hour after generation, got %d", expiresAt)
+	}
+}
@@ -22,6 +22,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &SynonymResource{}
 var _ resource.ResourceWithImportState = &SynonymResource{}
+var _ resource.ResourceWithUpgradeState = &SynonymResource{}
 
 func NewSynonymResource() resource.Resource {
 	return &SynonymResource{}
@@ -39,12 +40,22 @@ type SynonymResourceModel struct {
 	Synonyms       []types.String `tfsdk:"synonyms"`
 }
 
+// synonymResourceModelV0 is the state of schema version 0.
+type synonymResourceModelV0 struct {
+	Id             types.String   `tfsdk:"id"`
+	Name           types.String   `tfsdk:"name"`
+	CollectionName types.String   `tfsdk:"collection_name"`
+	Root           types.String   `tfsdk:"root"`
+	Synonyms       []types.String `tfsdk:"synonyms"`
+}
+
 func (r *SynonymResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_synonym"
 }
 
 func (r *SynonymResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:             1,
 		MarkdownDescription: "The synonyms feature allows you to define search terms that should be considered equivalent. For eg: when you define a synonym for sneaker as shoe, searching for sneaker will now return all records with the word shoe in them, in addition to records with the word sneaker.",
 
 		Attributes: map[string]schema.Attribute{
@@ -89,7 +100,7 @@ func (r *SynonymResource) Configure(ctx context.Context, req resource.ConfigureR
 		return
 	}
 
-	client, ok := req.ProviderData.(*typesense.Client)
+	client, ok := req.ProviderData.(*typesenseClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
@@ -100,7 +111,7 @@ func (r *SynonymResource) Configure(ctx context.Context, req resource.ConfigureR
 		return
 	}
 
-	r.client = client
+	r.client = client.Client
 }
 
 func (r *SynonymResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -247,6 +258,41 @@ func (r *SynonymResource) Delete(ctx context.Context, req resource.DeleteRequest
 	data.Id = types.StringValue("")
 }
 
+func (r *SynonymResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"id":              schema.StringAttribute{Computed: true},
+					"name":            schema.StringAttribute{Required: true},
+					"collection_name": schema.StringAttribute{Required: true},
+					"root":            schema.StringAttribute{Optional: true},
+					"synonyms":        schema.ListAttribute{Required: true, ElementType: types.StringType},
+				},
+			},
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior synonymResourceModelV0
+
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				data := SynonymResourceModel{
+					Id:             prior.Id,
+					Name:           prior.Name,
+					CollectionName: prior.CollectionName,
+					Root:           prior.Root,
+					Synonyms:       prior.Synonyms,
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			},
+		},
+	}
+}
+
 func (r *SynonymResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	// ID format is: collection_name.synonym_id
 	collectionName, synonymId, err := splitCollectionRelatedId(req.ID)
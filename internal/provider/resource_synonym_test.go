@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -200,3 +201,26 @@ resource "typesense_synonym" "test2" {
 }
 `, collectionName)
 }
+
+func TestSynonymResource_UpgradeStateV0(t *testing.T) {
+	// State written by the provider before schema versions were introduced
+	state := testUpgradeResourceState(t, &SynonymResource{}, 0, `{
+  "collection_name": "products",
+  "id": "products.shoes",
+  "name": "shoes",
+  "root": null,
+  "synonyms": ["shoe", "sneaker"]
+}`)
+
+	var data SynonymResourceModel
+	if diags := state.Get(context.Background(), &data); diags.HasError() {
+		t.Fatalf("unable to read upgraded state: %v", diags)
+	}
+
+	if data.Id.ValueString() != "products.shoes" || data.Name.ValueString() != "shoes" || data.CollectionName.ValueString() != "products" {
+		t.Errorf("expected synonym identity to be kept, got %+v", data)
+	}
+	if len(data.Synonyms) != 2 || !data.Root.IsNull() {
+		t.Errorf("expected synonyms to be kept, got %v with root %v", data.Synonyms, data.Root)
+	}
+}
@@ -58,3 +58,22 @@ func splitCollectionRelatedId(input string) (string, string, error) {
 func createId(collection string, resource string) string {
 	return fmt.Sprintf("%s.%s", collection, resource)
 }
+
+// keep only the include fields (when set) and drop the exclude fields of a document
+func filterDocumentFields(document map[string]interface{}, include []string, exclude []string) map[string]interface{} {
+	if len(include) > 0 {
+		filtered := make(map[string]interface{}, len(include))
+		for _, name := range include {
+			if value, ok := document[name]; ok {
+				filtered[name] = value
+			}
+		}
+		document = filtered
+	}
+
+	for _, name := range exclude {
+		delete(document, name)
+	}
+
+	return document
+}